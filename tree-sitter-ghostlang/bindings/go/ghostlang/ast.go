@@ -0,0 +1,475 @@
+// Code generated by gen.go from src/node-types.json; DO NOT EDIT.
+
+package ghostlang
+
+import "github.com/tree-sitter/tree-sitter-ghostlang/grove"
+
+// wrappers maps each named node kind to its wrapper's constructor.
+var wrappers = map[string]func(*grove.Node) AstNode{
+	"additive_expression":       func(n *grove.Node) AstNode { return AdditiveExpression{n} },
+	"argument_list":             func(n *grove.Node) AstNode { return ArgumentList{n} },
+	"array_literal":             func(n *grove.Node) AstNode { return ArrayLiteral{n} },
+	"assignment_expression":     func(n *grove.Node) AstNode { return AssignmentExpression{n} },
+	"block_statement":           func(n *grove.Node) AstNode { return BlockStatement{n} },
+	"boolean_literal":           func(n *grove.Node) AstNode { return BooleanLiteral{n} },
+	"call_expression":           func(n *grove.Node) AstNode { return CallExpression{n} },
+	"comment":                   func(n *grove.Node) AstNode { return Comment{n} },
+	"conditional_expression":    func(n *grove.Node) AstNode { return ConditionalExpression{n} },
+	"empty_statement":           func(n *grove.Node) AstNode { return EmptyStatement{n} },
+	"equality_expression":       func(n *grove.Node) AstNode { return EqualityExpression{n} },
+	"escape_sequence":           func(n *grove.Node) AstNode { return EscapeSequence{n} },
+	"expression":                func(n *grove.Node) AstNode { return Expression{n} },
+	"expression_statement":      func(n *grove.Node) AstNode { return ExpressionStatement{n} },
+	"for_statement":             func(n *grove.Node) AstNode { return ForStatement{n} },
+	"function_declaration":      func(n *grove.Node) AstNode { return FunctionDeclaration{n} },
+	"identifier":                func(n *grove.Node) AstNode { return Identifier{n} },
+	"if_statement":              func(n *grove.Node) AstNode { return IfStatement{n} },
+	"logical_and_expression":    func(n *grove.Node) AstNode { return LogicalAndExpression{n} },
+	"logical_or_expression":     func(n *grove.Node) AstNode { return LogicalOrExpression{n} },
+	"member_expression":         func(n *grove.Node) AstNode { return MemberExpression{n} },
+	"multiplicative_expression": func(n *grove.Node) AstNode { return MultiplicativeExpression{n} },
+	"null_literal":              func(n *grove.Node) AstNode { return NullLiteral{n} },
+	"number_literal":            func(n *grove.Node) AstNode { return NumberLiteral{n} },
+	"object_literal":            func(n *grove.Node) AstNode { return ObjectLiteral{n} },
+	"object_member":             func(n *grove.Node) AstNode { return ObjectMember{n} },
+	"parameter_list":            func(n *grove.Node) AstNode { return ParameterList{n} },
+	"postfix_expression":        func(n *grove.Node) AstNode { return PostfixExpression{n} },
+	"primary_expression":        func(n *grove.Node) AstNode { return PrimaryExpression{n} },
+	"relational_expression":     func(n *grove.Node) AstNode { return RelationalExpression{n} },
+	"return_statement":          func(n *grove.Node) AstNode { return ReturnStatement{n} },
+	"source_file":               func(n *grove.Node) AstNode { return SourceFile{n} },
+	"statement":                 func(n *grove.Node) AstNode { return Statement{n} },
+	"string_literal":            func(n *grove.Node) AstNode { return StringLiteral{n} },
+	"subscript_expression":      func(n *grove.Node) AstNode { return SubscriptExpression{n} },
+	"unary_expression":          func(n *grove.Node) AstNode { return UnaryExpression{n} },
+	"variable_declaration":      func(n *grove.Node) AstNode { return VariableDeclaration{n} },
+	"while_statement":           func(n *grove.Node) AstNode { return WhileStatement{n} },
+}
+
+// AdditiveExpression is an additive_expression node.
+type AdditiveExpression struct{ *grove.Node }
+
+func (n AdditiveExpression) GroveNode() *grove.Node { return n.Node }
+func (AdditiveExpression) astNode()                 {}
+
+// ArgumentList is an argument_list node.
+type ArgumentList struct{ *grove.Node }
+
+func (n ArgumentList) GroveNode() *grove.Node { return n.Node }
+func (ArgumentList) astNode()                 {}
+
+// ArrayLiteral is an array_literal node.
+type ArrayLiteral struct{ *grove.Node }
+
+func (n ArrayLiteral) GroveNode() *grove.Node { return n.Node }
+func (ArrayLiteral) astNode()                 {}
+
+// AssignmentExpression is an assignment_expression node.
+type AssignmentExpression struct{ *grove.Node }
+
+func (n AssignmentExpression) GroveNode() *grove.Node { return n.Node }
+func (AssignmentExpression) astNode()                 {}
+
+// Left returns the left field (postfix_expression), or nil.
+func (n AssignmentExpression) Left() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("left")
+}
+
+// Operator returns the operator field ("*=", "+=", "-=", "/=", "="), or nil.
+func (n AssignmentExpression) Operator() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("operator")
+}
+
+// Right returns the right field (expression), or nil.
+func (n AssignmentExpression) Right() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("right")
+}
+
+// BlockStatement is a block_statement node.
+type BlockStatement struct{ *grove.Node }
+
+func (n BlockStatement) GroveNode() *grove.Node { return n.Node }
+func (BlockStatement) astNode()                 {}
+
+// BooleanLiteral is a boolean_literal node.
+type BooleanLiteral struct{ *grove.Node }
+
+func (n BooleanLiteral) GroveNode() *grove.Node { return n.Node }
+func (BooleanLiteral) astNode()                 {}
+
+// CallExpression is a call_expression node.
+type CallExpression struct{ *grove.Node }
+
+func (n CallExpression) GroveNode() *grove.Node { return n.Node }
+func (CallExpression) astNode()                 {}
+
+// Arguments returns the arguments field (argument_list), or nil.
+func (n CallExpression) Arguments() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("arguments")
+}
+
+// Function returns the function field (postfix_expression), or nil.
+func (n CallExpression) Function() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("function")
+}
+
+// Comment is a comment node.
+type Comment struct{ *grove.Node }
+
+func (n Comment) GroveNode() *grove.Node { return n.Node }
+func (Comment) astNode()                 {}
+
+// ConditionalExpression is a conditional_expression node.
+type ConditionalExpression struct{ *grove.Node }
+
+func (n ConditionalExpression) GroveNode() *grove.Node { return n.Node }
+func (ConditionalExpression) astNode()                 {}
+
+// EmptyStatement is an empty_statement node.
+type EmptyStatement struct{ *grove.Node }
+
+func (n EmptyStatement) GroveNode() *grove.Node { return n.Node }
+func (EmptyStatement) astNode()                 {}
+
+// EqualityExpression is an equality_expression node.
+type EqualityExpression struct{ *grove.Node }
+
+func (n EqualityExpression) GroveNode() *grove.Node { return n.Node }
+func (EqualityExpression) astNode()                 {}
+
+// EscapeSequence is an escape_sequence node.
+type EscapeSequence struct{ *grove.Node }
+
+func (n EscapeSequence) GroveNode() *grove.Node { return n.Node }
+func (EscapeSequence) astNode()                 {}
+
+// Expression is an expression node.
+type Expression struct{ *grove.Node }
+
+func (n Expression) GroveNode() *grove.Node { return n.Node }
+func (Expression) astNode()                 {}
+
+// ExpressionStatement is an expression_statement node.
+type ExpressionStatement struct{ *grove.Node }
+
+func (n ExpressionStatement) GroveNode() *grove.Node { return n.Node }
+func (ExpressionStatement) astNode()                 {}
+
+// ForStatement is a for_statement node.
+type ForStatement struct{ *grove.Node }
+
+func (n ForStatement) GroveNode() *grove.Node { return n.Node }
+func (ForStatement) astNode()                 {}
+
+// Body returns the body field (statement), or nil.
+func (n ForStatement) Body() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("body")
+}
+
+// Condition returns the condition field (expression, which is optional), or nil.
+func (n ForStatement) Condition() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("condition")
+}
+
+// Init returns the init field (variable_declaration, which is optional), or nil.
+func (n ForStatement) Init() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("init")
+}
+
+// Iterable returns the iterable field (expression, which is optional), or nil.
+func (n ForStatement) Iterable() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("iterable")
+}
+
+// Update returns the update field (expression, which is optional), or nil.
+func (n ForStatement) Update() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("update")
+}
+
+// Variable returns the variable field (identifier, which is optional), or nil.
+func (n ForStatement) Variable() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("variable")
+}
+
+// FunctionDeclaration is a function_declaration node.
+type FunctionDeclaration struct{ *grove.Node }
+
+func (n FunctionDeclaration) GroveNode() *grove.Node { return n.Node }
+func (FunctionDeclaration) astNode()                 {}
+
+// Body returns the body field (block_statement), or nil.
+func (n FunctionDeclaration) Body() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("body")
+}
+
+// Name returns the name field (identifier), or nil.
+func (n FunctionDeclaration) Name() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("name")
+}
+
+// Parameters returns the parameters field (parameter_list), or nil.
+func (n FunctionDeclaration) Parameters() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("parameters")
+}
+
+// Identifier is an identifier node.
+type Identifier struct{ *grove.Node }
+
+func (n Identifier) GroveNode() *grove.Node { return n.Node }
+func (Identifier) astNode()                 {}
+
+// IfStatement is an if_statement node.
+type IfStatement struct{ *grove.Node }
+
+func (n IfStatement) GroveNode() *grove.Node { return n.Node }
+func (IfStatement) astNode()                 {}
+
+// Condition returns the condition field (expression), or nil.
+func (n IfStatement) Condition() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("condition")
+}
+
+// Else returns the else field (statement, which is optional), or nil.
+func (n IfStatement) Else() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("else")
+}
+
+// Then returns the then field (statement), or nil.
+func (n IfStatement) Then() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("then")
+}
+
+// LogicalAndExpression is a logical_and_expression node.
+type LogicalAndExpression struct{ *grove.Node }
+
+func (n LogicalAndExpression) GroveNode() *grove.Node { return n.Node }
+func (LogicalAndExpression) astNode()                 {}
+
+// LogicalOrExpression is a logical_or_expression node.
+type LogicalOrExpression struct{ *grove.Node }
+
+func (n LogicalOrExpression) GroveNode() *grove.Node { return n.Node }
+func (LogicalOrExpression) astNode()                 {}
+
+// MemberExpression is a member_expression node.
+type MemberExpression struct{ *grove.Node }
+
+func (n MemberExpression) GroveNode() *grove.Node { return n.Node }
+func (MemberExpression) astNode()                 {}
+
+// Object returns the object field (postfix_expression), or nil.
+func (n MemberExpression) Object() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("object")
+}
+
+// Property returns the property field (identifier), or nil.
+func (n MemberExpression) Property() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("property")
+}
+
+// MultiplicativeExpression is a multiplicative_expression node.
+type MultiplicativeExpression struct{ *grove.Node }
+
+func (n MultiplicativeExpression) GroveNode() *grove.Node { return n.Node }
+func (MultiplicativeExpression) astNode()                 {}
+
+// NullLiteral is a null_literal node.
+type NullLiteral struct{ *grove.Node }
+
+func (n NullLiteral) GroveNode() *grove.Node { return n.Node }
+func (NullLiteral) astNode()                 {}
+
+// NumberLiteral is a number_literal node.
+type NumberLiteral struct{ *grove.Node }
+
+func (n NumberLiteral) GroveNode() *grove.Node { return n.Node }
+func (NumberLiteral) astNode()                 {}
+
+// ObjectLiteral is an object_literal node.
+type ObjectLiteral struct{ *grove.Node }
+
+func (n ObjectLiteral) GroveNode() *grove.Node { return n.Node }
+func (ObjectLiteral) astNode()                 {}
+
+// ObjectMember is an object_member node.
+type ObjectMember struct{ *grove.Node }
+
+func (n ObjectMember) GroveNode() *grove.Node { return n.Node }
+func (ObjectMember) astNode()                 {}
+
+// ParameterList is a parameter_list node.
+type ParameterList struct{ *grove.Node }
+
+func (n ParameterList) GroveNode() *grove.Node { return n.Node }
+func (ParameterList) astNode()                 {}
+
+// PostfixExpression is a postfix_expression node.
+type PostfixExpression struct{ *grove.Node }
+
+func (n PostfixExpression) GroveNode() *grove.Node { return n.Node }
+func (PostfixExpression) astNode()                 {}
+
+// PrimaryExpression is a primary_expression node.
+type PrimaryExpression struct{ *grove.Node }
+
+func (n PrimaryExpression) GroveNode() *grove.Node { return n.Node }
+func (PrimaryExpression) astNode()                 {}
+
+// RelationalExpression is a relational_expression node.
+type RelationalExpression struct{ *grove.Node }
+
+func (n RelationalExpression) GroveNode() *grove.Node { return n.Node }
+func (RelationalExpression) astNode()                 {}
+
+// ReturnStatement is a return_statement node.
+type ReturnStatement struct{ *grove.Node }
+
+func (n ReturnStatement) GroveNode() *grove.Node { return n.Node }
+func (ReturnStatement) astNode()                 {}
+
+// SourceFile is a source_file node.
+type SourceFile struct{ *grove.Node }
+
+func (n SourceFile) GroveNode() *grove.Node { return n.Node }
+func (SourceFile) astNode()                 {}
+
+// Statement is a statement node.
+type Statement struct{ *grove.Node }
+
+func (n Statement) GroveNode() *grove.Node { return n.Node }
+func (Statement) astNode()                 {}
+
+// StringLiteral is a string_literal node.
+type StringLiteral struct{ *grove.Node }
+
+func (n StringLiteral) GroveNode() *grove.Node { return n.Node }
+func (StringLiteral) astNode()                 {}
+
+// SubscriptExpression is a subscript_expression node.
+type SubscriptExpression struct{ *grove.Node }
+
+func (n SubscriptExpression) GroveNode() *grove.Node { return n.Node }
+func (SubscriptExpression) astNode()                 {}
+
+// Index returns the index field (expression), or nil.
+func (n SubscriptExpression) Index() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("index")
+}
+
+// Object returns the object field (postfix_expression), or nil.
+func (n SubscriptExpression) Object() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("object")
+}
+
+// UnaryExpression is an unary_expression node.
+type UnaryExpression struct{ *grove.Node }
+
+func (n UnaryExpression) GroveNode() *grove.Node { return n.Node }
+func (UnaryExpression) astNode()                 {}
+
+// VariableDeclaration is a variable_declaration node.
+type VariableDeclaration struct{ *grove.Node }
+
+func (n VariableDeclaration) GroveNode() *grove.Node { return n.Node }
+func (VariableDeclaration) astNode()                 {}
+
+// Name returns the name field (identifier), or nil.
+func (n VariableDeclaration) Name() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("name")
+}
+
+// Value returns the value field (expression), or nil.
+func (n VariableDeclaration) Value() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("value")
+}
+
+// WhileStatement is a while_statement node.
+type WhileStatement struct{ *grove.Node }
+
+func (n WhileStatement) GroveNode() *grove.Node { return n.Node }
+func (WhileStatement) astNode()                 {}
+
+// Body returns the body field (statement), or nil.
+func (n WhileStatement) Body() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("body")
+}
+
+// Condition returns the condition field (expression), or nil.
+func (n WhileStatement) Condition() *grove.Node {
+	if n.Node == nil {
+		return nil
+	}
+	return n.ChildByFieldName("condition")
+}
@@ -0,0 +1,102 @@
+package ghostlang_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/ghostlang"
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func parse(t *testing.T, src string) *grove.Tree {
+	t.Helper()
+	p, err := grove.NewParser(ghostlang.Language())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	t.Cleanup(tree.Close)
+	return tree
+}
+
+func TestWrap(t *testing.T) {
+	tree := parse(t, "function greet(name) { return name; }\nvar x = greet(1);\n")
+	root := tree.RootNode()
+
+	var got []string
+	for i := 0; i < root.NamedChildCount(); i++ {
+		switch n := ghostlang.Wrap(root.NamedChild(i).NamedChild(0)).(type) {
+		case ghostlang.FunctionDeclaration:
+			got = append(got, "function "+n.Name().Utf8Text()+" "+n.Parameters().Utf8Text())
+			if n.Body().Kind() != "block_statement" {
+				t.Errorf("Body is a %s", n.Body().Kind())
+			}
+		case ghostlang.VariableDeclaration:
+			value, ok := ghostlang.Wrap(n.Value()).(ghostlang.Expression)
+			if !ok {
+				t.Fatalf("Wrap(value) is a %T", ghostlang.Wrap(n.Value()))
+			}
+			got = append(got, "var "+n.Name().Utf8Text()+" = "+value.Utf8Text())
+		default:
+			t.Errorf("Wrap(statement %d) is a %T", i, n)
+		}
+	}
+	if want := "function greet (name)|var x = greet(1)"; strings.Join(got, "|") != want {
+		t.Errorf("got %q, want %q", strings.Join(got, "|"), want)
+	}
+
+	if _, ok := ghostlang.Wrap(root).(ghostlang.SourceFile); !ok {
+		t.Errorf("Wrap(root) is a %T", ghostlang.Wrap(root))
+	}
+	if tok, ok := ghostlang.Wrap(root.NamedChild(0).NamedChild(0).Child(0)).(ghostlang.Token); !ok || tok.Kind() != "function" {
+		t.Errorf("Wrap(keyword) = %#v", tok)
+	}
+	if ghostlang.Wrap(nil) != nil {
+		t.Errorf("Wrap(nil) is not nil")
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	tree := parse(t, "var = ;\n")
+	var found bool
+	var walk func(n *grove.Node)
+	walk = func(n *grove.Node) {
+		if n.IsError() {
+			if _, ok := ghostlang.Wrap(n).(ghostlang.Error); !ok {
+				t.Errorf("Wrap(ERROR) is a %T", ghostlang.Wrap(n))
+			}
+			found = true
+		}
+		for i := 0; i < n.ChildCount(); i++ {
+			walk(n.Child(i))
+		}
+	}
+	walk(tree.RootNode())
+	if !found {
+		t.Fatal("no ERROR node in tree")
+	}
+}
+
+func TestFieldsAreNilSafe(t *testing.T) {
+	var fn ghostlang.FunctionDeclaration
+	if fn.Name() != nil || fn.Body() != nil || fn.GroveNode() != nil {
+		t.Errorf("zero FunctionDeclaration has non-nil fields")
+	}
+
+	tree := parse(t, "if (x) { }\n")
+	stmt, ok := ghostlang.Wrap(tree.RootNode().NamedChild(0).NamedChild(0)).(ghostlang.IfStatement)
+	if !ok {
+		t.Fatalf("Wrap(if) is a %T", ghostlang.Wrap(tree.RootNode().NamedChild(0).NamedChild(0)))
+	}
+	if stmt.Else() != nil {
+		t.Errorf("Else of an if without else = %s", stmt.Else().Kind())
+	}
+	if stmt.Then() == nil || stmt.Condition() == nil {
+		t.Errorf("Then or Condition of an if is nil")
+	}
+}
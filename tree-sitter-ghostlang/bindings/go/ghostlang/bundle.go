@@ -0,0 +1,32 @@
+package ghostlang
+
+//go:generate cp ../../../queries/highlights.scm ../../../queries/injections.scm ../../../queries/locals.scm ../../../queries/folds.scm ../../../queries/indents.scm ../../../queries/tags.scm queries/
+
+import (
+	"embed"
+	"sync"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// Queries holds the grammar's query files for Bundle under queries/, copied
+// from the repository's queries directory by go generate.
+//
+//go:embed queries/*.scm
+var Queries embed.FS
+
+var bundle = sync.OnceValue(func() *grove.LanguageBundle {
+	b, err := grove.LoadBundleFS(Queries, "queries", Language())
+	if err != nil {
+		panic(err)
+	}
+	return b
+})
+
+// Bundle returns Ghostlang with its highlights, injections, locals, folds,
+// indents and tags queries, compiled from Queries on first use:
+//
+//	spans, err := ghostlang.Bundle().Highlighter(nil).Highlight(src)
+func Bundle() *grove.LanguageBundle {
+	return bundle()
+}
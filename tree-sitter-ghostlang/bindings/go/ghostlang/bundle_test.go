@@ -0,0 +1,53 @@
+package ghostlang_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/ghostlang"
+)
+
+func TestQueriesMatchRepository(t *testing.T) {
+	names := []string{"highlights", "injections", "locals", "folds", "indents", "tags"}
+	for _, name := range names {
+		want, err := os.ReadFile("../../../queries/" + name + ".scm")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := fs.ReadFile(ghostlang.Queries, "queries/"+name+".scm")
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("embedded %s.scm is out of date; run go generate", name)
+		}
+	}
+	embedded, err := fs.Glob(ghostlang.Queries, "queries/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(embedded) != len(names) {
+		t.Errorf("embedded queries are %v, want only the bundle's %v", embedded, names)
+	}
+}
+
+func TestBundle(t *testing.T) {
+	b := ghostlang.Bundle()
+	if b != ghostlang.Bundle() {
+		t.Errorf("Bundle compiled the queries twice")
+	}
+	if b.Language != ghostlang.Language() {
+		t.Errorf("Bundle language is %s", b.Language.Name())
+	}
+	if b.Highlights == nil || b.Injections == nil || b.Locals == nil || b.Folds == nil || b.Indents == nil || b.Tags == nil {
+		t.Fatalf("Bundle is missing queries: %+v", b)
+	}
+
+	tree := parse(t, "function greet(name) { return name; }\n")
+	tags, err := b.TagExtractor().Tags(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) == 0 || tags[0].Name != "greet" {
+		t.Errorf("tags = %+v, want greet first", tags)
+	}
+}
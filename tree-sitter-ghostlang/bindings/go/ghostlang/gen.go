@@ -0,0 +1,133 @@
+//go:build ignore
+
+// gen.go writes ast.go, the typed wrappers for Ghostlang node kinds, from the
+// grammar's node-types.json. Run it with go generate after regenerating the
+// parser.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+type nodeType struct {
+	Type     string               `json:"type"`
+	Named    bool                 `json:"named"`
+	Fields   map[string]fieldInfo `json:"fields"`
+	Subtypes []json.RawMessage    `json:"subtypes"`
+}
+
+type fieldInfo struct {
+	Multiple bool `json:"multiple"`
+	Required bool `json:"required"`
+	Types    []struct {
+		Type  string `json:"type"`
+		Named bool   `json:"named"`
+	} `json:"types"`
+}
+
+func main() {
+	data, err := os.ReadFile("../../../src/node-types.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	var types []nodeType
+	if err := json.Unmarshal(data, &types); err != nil {
+		log.Fatal(err)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Type < types[j].Type })
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go from src/node-types.json; DO NOT EDIT.\n\n")
+	b.WriteString("package ghostlang\n\n")
+	b.WriteString("import \"github.com/tree-sitter/tree-sitter-ghostlang/grove\"\n\n")
+
+	var kinds []nodeType
+	for _, t := range types {
+		// Supertypes never appear in trees; their subtypes do.
+		if t.Named && len(t.Subtypes) == 0 {
+			kinds = append(kinds, t)
+		}
+	}
+
+	b.WriteString("// wrappers maps each named node kind to its wrapper's constructor.\n")
+	b.WriteString("var wrappers = map[string]func(*grove.Node) AstNode{\n")
+	for _, t := range kinds {
+		fmt.Fprintf(&b, "\t%q: func(n *grove.Node) AstNode { return %s{n} },\n", t.Type, goName(t.Type))
+	}
+	b.WriteString("}\n")
+
+	for _, t := range kinds {
+		name := goName(t.Type)
+		fmt.Fprintf(&b, "\n// %s is %s %s node.\n", name, article(t.Type), t.Type)
+		fmt.Fprintf(&b, "type %s struct{ *grove.Node }\n\n", name)
+		fmt.Fprintf(&b, "func (n %s) GroveNode() *grove.Node { return n.Node }\n", name)
+		fmt.Fprintf(&b, "func (%s) astNode()                  {}\n", name)
+
+		fields := make([]string, 0, len(t.Fields))
+		for field := range t.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			info := t.Fields[field]
+			var kinds []string
+			for _, k := range info.Types {
+				if k.Named {
+					kinds = append(kinds, k.Type)
+				} else {
+					kinds = append(kinds, fmt.Sprintf("%q", k.Type))
+				}
+			}
+			method := goName(field)
+			of := strings.Join(kinds, ", ")
+			if info.Multiple {
+				fmt.Fprintf(&b, "\n// %s returns the nodes in the %s field (%s), or nil.\n", method, field, of)
+				fmt.Fprintf(&b, "func (n %s) %s() []*grove.Node {\n", name, method)
+				b.WriteString("\tif n.Node == nil {\n\t\treturn nil\n\t}\n")
+				fmt.Fprintf(&b, "\treturn n.ChildrenByFieldName(%q)\n}\n", field)
+				continue
+			}
+			optional := ""
+			if !info.Required {
+				optional = ", which is optional"
+			}
+			fmt.Fprintf(&b, "\n// %s returns the %s field (%s%s), or nil.\n", method, field, of, optional)
+			fmt.Fprintf(&b, "func (n %s) %s() *grove.Node {\n", name, method)
+			b.WriteString("\tif n.Node == nil {\n\t\treturn nil\n\t}\n")
+			fmt.Fprintf(&b, "\treturn n.ChildByFieldName(%q)\n}\n", field)
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("ast.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// goName turns a snake_case grammar name into an exported Go name.
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+func article(word string) string {
+	if strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
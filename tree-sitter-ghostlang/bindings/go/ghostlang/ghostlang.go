@@ -0,0 +1,97 @@
+// Package ghostlang gives the node kinds of the Ghostlang grammar Go types,
+// so that tree-walking code can switch on types rather than compare kind
+// strings:
+//
+//	switch n := ghostlang.Wrap(node).(type) {
+//	case ghostlang.FunctionDeclaration:
+//		fmt.Println("function", n.Name().Utf8Text())
+//	case ghostlang.VariableDeclaration:
+//		fmt.Println("variable", n.Name().Utf8Text())
+//	}
+//
+// The wrappers in ast.go are generated from the grammar's node-types.json.
+// Each embeds the *grove.Node it wraps and adds an accessor per grammar
+// field. Accessors return nil for an absent field, and for a zero wrapper.
+//
+// Bundle provides the grammar's queries, for highlighting, folding and the
+// other query-driven features of grove.
+package ghostlang
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+
+	// The binding registers the grammar with grove.
+	_ "github.com/tree-sitter/tree-sitter-ghostlang"
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// AstNode is a node wrapped in the type for its kind. It is implemented
+// only by the types of this package.
+type AstNode interface {
+	// GroveNode returns the wrapped node.
+	GroveNode() *grove.Node
+	astNode()
+}
+
+// Token is an anonymous node, a literal token of the grammar such as "var"
+// or ";". Its Kind is the token text.
+type Token struct{ *grove.Node }
+
+func (n Token) GroveNode() *grove.Node { return n.Node }
+func (Token) astNode()                 {}
+
+// Error is an ERROR node, holding text the parser could not fit into the
+// grammar.
+type Error struct{ *grove.Node }
+
+func (n Error) GroveNode() *grove.Node { return n.Node }
+func (Error) astNode()                 {}
+
+// Language returns the Ghostlang language the wrappers are generated for.
+func Language() *grove.Language {
+	return language
+}
+
+var (
+	language *grove.Language
+	// byKindID holds the constructor for each named kind, by kind id.
+	byKindID []func(*grove.Node) AstNode
+)
+
+func init() {
+	lang, ok := grove.Get("ghostlang")
+	if !ok {
+		panic("ghostlang: grammar not registered")
+	}
+	language = lang
+	byKindID = make([]func(*grove.Node) AstNode, lang.NodeKindCount())
+	for id := range byKindID {
+		if lang.NodeKindIsNamed(uint16(id)) {
+			byKindID[id] = wrappers[lang.NodeKindForID(uint16(id))]
+		}
+	}
+}
+
+// Wrap returns n wrapped in the type for its kind: one of the generated
+// types for a named node, Error for an ERROR node and Token for an
+// anonymous one. A MISSING node gets the type of the kind it stands in for.
+// Wrap returns nil for a nil node and panics for a node that is not from a
+// Ghostlang tree.
+func Wrap(n *grove.Node) AstNode {
+	if n == nil {
+		return nil
+	}
+	if lang := n.Tree().Language(); lang != language {
+		panic(fmt.Sprintf("ghostlang: Wrap of a %s node", lang.Name()))
+	}
+	switch id := n.KindID(); {
+	case n.IsError():
+		return Error{n}
+	case int(id) < len(byKindID) && byKindID[id] != nil:
+		return byKindID[id](n)
+	default:
+		return Token{n}
+	}
+}
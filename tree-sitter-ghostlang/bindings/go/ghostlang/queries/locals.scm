@@ -0,0 +1,43 @@
+; Local scope queries for Ghostlang
+; These help Grove understand variable scoping and references
+
+; Scopes
+(source_file) @local.scope
+(function_declaration) @local.scope
+(block_statement) @local.scope
+(for_statement) @local.scope
+
+; Function definitions create their own scope. The function's name belongs
+; to the scope around it, so that callers can see it, which is marked with
+; the definition.<kind>.scope property used by nvim-treesitter.
+((function_declaration
+  name: (identifier) @local.definition.function) @local.symbol.function
+ (#set! definition.function.scope "parent"))
+
+; Variable definitions
+(variable_declaration
+  name: (identifier) @local.definition.variable) @local.symbol.variable
+
+(for_statement
+  variable: (identifier) @local.definition.variable)
+
+; Parameter definitions
+(parameter_list
+  (identifier) @local.definition.parameter)
+
+; Variable references. Identifiers elsewhere, such as member properties and
+; object keys, are names rather than references.
+(primary_expression
+  (identifier) @local.reference)
+
+; Function calls - function name is a reference
+(call_expression
+  function: (postfix_expression
+    (primary_expression
+      (identifier) @local.reference)))
+
+; Assignment targets are references (they must exist)
+(assignment_expression
+  left: (postfix_expression
+    (primary_expression
+      (identifier) @local.reference)))
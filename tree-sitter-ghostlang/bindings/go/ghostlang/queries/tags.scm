@@ -0,0 +1,20 @@
+; Tag queries for Ghostlang
+; These let Grove index definitions and references for symbol search
+
+; Function declarations, with the comments directly above them as docs
+((comment)* @doc
+ .
+ (statement
+   (function_declaration
+     name: (identifier) @name) @definition.function)
+ (#select-adjacent! @doc @definition.function))
+
+; Variable declarations
+(variable_declaration
+  name: (identifier) @name) @definition.variable
+
+; Function calls
+(call_expression
+  function: (postfix_expression
+    (primary_expression
+      (identifier) @name))) @reference.call
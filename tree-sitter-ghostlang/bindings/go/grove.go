@@ -0,0 +1,17 @@
+package tree_sitter_ghostlang
+
+import "github.com/tree-sitter/tree-sitter-ghostlang/grove"
+
+func init() {
+	lang, err := grove.NewLanguage("ghostlang", Language(),
+		grove.WithCommentKinds("comment"),
+		grove.WithStringKinds("string_literal"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	grove.Register("ghostlang", lang)
+	grove.RegisterByExtension(".gza", lang)
+	grove.RegisterByExtension(".ghost", lang)
+	grove.RegisterByInterpreter("ghostlang", lang)
+}
@@ -0,0 +1,67 @@
+package grove
+
+// bracketPairs maps each delimiter token to its partner.
+var bracketPairs = map[string]string{
+	"(": ")", ")": "(",
+	"[": "]", "]": "[",
+	"{": "}", "}": "{",
+}
+
+func isOpeningBracket(kind string) bool {
+	return kind == "(" || kind == "[" || kind == "{"
+}
+
+// MatchingBracket returns the start offset of the delimiter paired with the
+// one starting at offset. It works from either side of a pair. Pairs come
+// from the tree rather than from counting characters, so delimiters inside
+// strings and comments are never matched. It reports false when no
+// delimiter token starts at offset, or when the delimiter is unbalanced:
+// inside an ERROR node or paired with a MISSING one.
+func (t *Tree) MatchingBracket(offset uint32) (uint32, bool) {
+	n := t.RootNode().DescendantForByteRange(offset, offset+1)
+	if n == nil || n.IsNamed() || n.StartByte() != offset {
+		return 0, false
+	}
+	kind := n.Kind()
+	partner, ok := bracketPairs[kind]
+	if !ok || n.IsMissing() {
+		return 0, false
+	}
+	parent := n.ts.Parent()
+	if parent == nil || parent.IsError() {
+		return 0, false
+	}
+
+	step, i := 1, -1
+	count := int(parent.ChildCount())
+	for j := 0; j < count; j++ {
+		if c := parent.Child(j); c.StartByte() == offset && c.Type() == kind {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		return 0, false
+	}
+	if !isOpeningBracket(kind) {
+		step = -1
+	}
+	depth := 0
+	for j := i + step; j >= 0 && j < count; j += step {
+		c := parent.Child(j)
+		switch c.Type() {
+		case kind:
+			depth++
+		case partner:
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if c.IsMissing() {
+				return 0, false
+			}
+			return c.StartByte(), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchingBracket(t *testing.T) {
+	src := `function f(a) { var s = "}{)"; /* ( */ return [a, (a)]; }`
+	tree := parse(t, src)
+	defer tree.Close()
+
+	// Each pair gives a marker and the delimiter's offset within it.
+	type at struct {
+		marker string
+		skip   int
+	}
+	pairs := [][2]at{
+		{{"f(", 1}, {") {", 0}},
+		{{"{ var", 0}, {"; }", 2}},
+		{{"[a", 0}, {"];", 0}},
+		{{", (a", 2}, {"a)]", 1}},
+	}
+	for _, p := range pairs {
+		open := uint32(strings.Index(src, p[0].marker) + p[0].skip)
+		close := uint32(strings.Index(src, p[1].marker) + p[1].skip)
+		if got, ok := tree.MatchingBracket(open); !ok || got != close {
+			t.Errorf("MatchingBracket(%d %q) = %d, %v; want %d", open, src[open], got, ok, close)
+		}
+		if got, ok := tree.MatchingBracket(close); !ok || got != open {
+			t.Errorf("MatchingBracket(%d %q) = %d, %v; want %d", close, src[close], got, ok, open)
+		}
+	}
+
+	for _, s := range []string{"}{)", "( */", "var"} {
+		offset := uint32(strings.Index(src, s))
+		if got, ok := tree.MatchingBracket(offset); ok {
+			t.Errorf("MatchingBracket(%d %q) = %d, want no match", offset, src[offset], got)
+		}
+	}
+}
+
+func TestMatchingBracketUnbalanced(t *testing.T) {
+	src := "var x = [1, 2;\nfoo(];\n"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	for i := 0; i < len(src); i++ {
+		if !strings.ContainsRune("([{}])", rune(src[i])) {
+			continue
+		}
+		if got, ok := tree.MatchingBracket(uint32(i)); ok {
+			t.Errorf("MatchingBracket(%d %q) = %d, want no match in %s", i, src[i], got, tree)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package grove
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// LanguageBundle groups a language with the queries that drive grove's
+// query-based features, as a grammar ships them. Queries the grammar does
+// not provide are nil.
+type LanguageBundle struct {
+	Language   *Language
+	Highlights *Query
+	Injections *Query
+	Locals     *Query
+	Folds      *Query
+	Indents    *Query
+	Tags       *Query
+}
+
+// LoadBundleFS loads the queries for lang from the conventional files in dir
+// of fsys: highlights.scm, injections.scm, locals.scm, folds.scm,
+// indents.scm and tags.scm. A missing file leaves its query nil, but a file
+// that cannot be read or compiled fails the load with an error naming it,
+// closing the queries already compiled. fsys is typically an embed.FS
+// holding a grammar's queries directory.
+func LoadBundleFS(fsys fs.FS, dir string, lang *Language) (_ *LanguageBundle, err error) {
+	b := &LanguageBundle{Language: lang}
+	files := []struct {
+		name  string
+		query **Query
+	}{
+		{"highlights", &b.Highlights},
+		{"injections", &b.Injections},
+		{"locals", &b.Locals},
+		{"folds", &b.Folds},
+		{"indents", &b.Indents},
+		{"tags", &b.Tags},
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range files {
+				if *q.query != nil {
+					(*q.query).Close()
+				}
+			}
+		}
+	}()
+	for _, q := range files {
+		file := path.Join(dir, q.name+".scm")
+		source, err := fs.ReadFile(fsys, file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("grove: loading %s queries: %w", lang.Name(), err)
+		}
+		if *q.query, err = NewQuery(lang, string(source)); err != nil {
+			return nil, fmt.Errorf("grove: %s: %w", file, err)
+		}
+	}
+	return b, nil
+}
+
+// Highlighter returns a highlighter for the bundle's highlights query, with
+// classes as for NewHighlighter, or nil if the bundle has none.
+func (b *LanguageBundle) Highlighter(classes map[string]int) *Highlighter {
+	if b.Highlights == nil {
+		return nil
+	}
+	return NewHighlighter(b.Highlights, classes)
+}
+
+// Injector returns an injector for the bundle's injections query, resolving
+// languages in registry as for NewInjector, or nil if the bundle has none.
+func (b *LanguageBundle) Injector(registry *Registry) *Injector {
+	if b.Injections == nil {
+		return nil
+	}
+	return NewInjector(b.Injections, registry)
+}
+
+// LocalsResolver returns a resolver for the bundle's locals query, or nil if
+// the bundle has none.
+func (b *LanguageBundle) LocalsResolver() *LocalsResolver {
+	if b.Locals == nil {
+		return nil
+	}
+	return NewLocalsResolver(b.Locals)
+}
+
+// FoldProvider returns a fold provider for the bundle's folds query, or nil
+// if the bundle has none.
+func (b *LanguageBundle) FoldProvider(opts ...FoldOption) *FoldProvider {
+	if b.Folds == nil {
+		return nil
+	}
+	return NewFoldProvider(b.Folds, opts...)
+}
+
+// Indenter returns an indenter for the bundle's indents query, or nil if the
+// bundle has none.
+func (b *LanguageBundle) Indenter() *Indenter {
+	if b.Indents == nil {
+		return nil
+	}
+	return NewIndenter(b.Indents)
+}
+
+// TagExtractor returns a tag extractor for the bundle's tags query, or nil
+// if the bundle has none.
+func (b *LanguageBundle) TagExtractor() *TagExtractor {
+	if b.Tags == nil {
+		return nil
+	}
+	return NewTagExtractor(b.Tags)
+}
@@ -0,0 +1,66 @@
+package grove_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestLoadBundleFS(t *testing.T) {
+	b, err := grove.LoadBundleFS(os.DirFS("../../.."), "queries", ghostlang(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, q := range map[string]*grove.Query{
+		"highlights": b.Highlights,
+		"injections": b.Injections,
+		"locals":     b.Locals,
+		"folds":      b.Folds,
+		"indents":    b.Indents,
+		"tags":       b.Tags,
+	} {
+		if q == nil {
+			t.Errorf("%s query not loaded", name)
+		}
+	}
+
+	spans, err := b.Highlighter(nil).Highlight([]byte("var x = 5;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) == 0 || spans[0].Capture != "keyword" {
+		t.Errorf("highlights = %v, want a keyword first", spans)
+	}
+	tree := parse(t, "function f() {\n  return 1;\n}\n")
+	defer tree.Close()
+	if folds := b.FoldProvider().Folds(tree); len(folds) == 0 {
+		t.Errorf("no folds from the bundle's folds query")
+	}
+}
+
+func TestLoadBundleFSMissingAndInvalid(t *testing.T) {
+	fsys := fstest.MapFS{
+		"q/highlights.scm": {Data: []byte("(identifier) @variable")},
+	}
+	b, err := grove.LoadBundleFS(fsys, "q", ghostlang(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Highlights == nil || b.Language != ghostlang(t) {
+		t.Errorf("bundle = %+v, want the language and its highlights", b)
+	}
+	if b.Folds != nil || b.FoldProvider() != nil || b.TagExtractor() != nil || b.Injector(nil) != nil {
+		t.Errorf("missing queries did not leave the bundle's features nil")
+	}
+
+	fsys["q/tags.scm"] = &fstest.MapFile{Data: []byte("(no_such_kind) @name")}
+	_, err = grove.LoadBundleFS(fsys, "q", ghostlang(t))
+	var qe *grove.QueryError
+	if !errors.As(err, &qe) || !strings.Contains(err.Error(), "q/tags.scm") {
+		t.Errorf("LoadBundleFS with an invalid tags query = %v, want a QueryError naming q/tags.scm", err)
+	}
+}
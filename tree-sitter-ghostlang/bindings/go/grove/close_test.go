@@ -0,0 +1,110 @@
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// panicsClosed reports whether fn panics with grove.ErrClosed.
+func panicsClosed(fn func()) (closed bool) {
+	defer func() {
+		err, _ := recover().(error)
+		closed = errors.Is(err, grove.ErrClosed)
+	}()
+	fn()
+	return false
+}
+
+func TestClosedTree(t *testing.T) {
+	tree := parse(t, "var x = 1;")
+	root := tree.RootNode()
+	child := root.NamedChild(0)
+	tree.Close()
+	tree.Close()
+
+	for name, fn := range map[string]func(){
+		"RootNode":   func() { tree.RootNode() },
+		"Copy":       func() { tree.Copy() },
+		"Kind":       func() { child.Kind() },
+		"Text":       func() { child.Text() },
+		"NamedChild": func() { root.NamedChild(0) },
+		"Walk":       func() { tree.Walk() },
+	} {
+		if !panicsClosed(fn) {
+			t.Errorf("%s on a closed tree did not panic with ErrClosed", name)
+		}
+	}
+
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	if _, err := p.ParseIncremental(context.Background(), tree, []byte("var x = 2;")); !errors.Is(err, grove.ErrClosed) {
+		t.Errorf("ParseIncremental from a closed tree = %v, want ErrClosed", err)
+	}
+}
+
+func TestClosedParser(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	p.Close()
+	p.Close()
+	if _, err := p.Parse(context.Background(), []byte("var x = 1;")); !errors.Is(err, grove.ErrClosed) {
+		t.Errorf("Parse on a closed parser = %v, want ErrClosed", err)
+	}
+	if err := p.SetIncludedRanges(nil); !errors.Is(err, grove.ErrClosed) {
+		t.Errorf("SetIncludedRanges on a closed parser = %v, want ErrClosed", err)
+	}
+	if !panicsClosed(func() { p.SetLogger(nil) }) {
+		t.Errorf("SetLogger on a closed parser did not panic with ErrClosed")
+	}
+	if !panicsClosed(p.Reset) {
+		t.Errorf("Reset on a closed parser did not panic with ErrClosed")
+	}
+
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	defer pool.Close()
+	q, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+	pool.Put(q)
+	if q, err = pool.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(q)
+	if _, err := q.Parse(context.Background(), []byte("var x = 1;")); err != nil {
+		t.Errorf("pool handed out a closed parser: %v", err)
+	}
+}
+
+func TestClosedQueryAndCursor(t *testing.T) {
+	tree := parse(t, "var x = 1;")
+	defer tree.Close()
+
+	source := "(identifier) @id ; closed query test"
+	q, err := grove.NewQuery(ghostlang(t), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Close()
+	q.Close()
+	if !panicsClosed(func() { q.Matches(tree.RootNode()) }) {
+		t.Errorf("Matches on a closed query did not panic with ErrClosed")
+	}
+	again, err := grove.NewQuery(ghostlang(t), source)
+	if err != nil || again == q {
+		t.Errorf("NewQuery returned the closed query from its cache")
+	}
+	if len(matchTexts(t, tree, source, "id")) != 1 {
+		t.Errorf("recompiled query does not match")
+	}
+
+	c := tree.Walk()
+	c.Close()
+	c.Close()
+	if !panicsClosed(func() { c.GoToFirstChild() }) {
+		t.Errorf("GoToFirstChild on a closed cursor did not panic with ErrClosed")
+	}
+}
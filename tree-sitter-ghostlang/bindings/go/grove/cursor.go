@@ -0,0 +1,88 @@
+package grove
+
+import "runtime"
+
+// TreeCursor walks a tree one step at a time. Moving the cursor does not
+// allocate, and Reset lets one cursor traverse many subtrees.
+//
+// A TreeCursor is not safe for concurrent use. The cursor, and the nodes it
+// yields, are only valid while their tree is; call Close to free the cursor
+// before or after closing the tree. A closed cursor, or one whose tree is
+// closed, panics with ErrClosed. A cursor that is never closed is freed when
+// it is garbage collected.
+type TreeCursor struct {
+	tree   *Tree
+	cur    tsCursor
+	closed bool
+}
+
+// Walk returns a cursor positioned at the root node.
+func (t *Tree) Walk() *TreeCursor {
+	return NewTreeCursor(t.RootNode())
+}
+
+// NewTreeCursor returns a cursor positioned at node. The cursor treats node
+// as the top of the tree: it cannot move to node's parent or siblings.
+func NewTreeCursor(node *Node) *TreeCursor {
+	c := &TreeCursor{tree: node.tree}
+	c.cur.init(node.live())
+	runtime.SetFinalizer(c, (*TreeCursor).Close)
+	return c
+}
+
+// GoToFirstChild moves to the first child of the current node, reporting
+// false and staying put if it has none.
+func (c *TreeCursor) GoToFirstChild() bool {
+	return c.live().gotoFirstChild()
+}
+
+// GoToNextSibling moves to the next sibling of the current node, reporting
+// false and staying put if there is none.
+func (c *TreeCursor) GoToNextSibling() bool {
+	return c.live().gotoNextSibling()
+}
+
+// GoToParent moves to the parent of the current node, reporting false and
+// staying put at the node the cursor started from.
+func (c *TreeCursor) GoToParent() bool {
+	return c.live().gotoParent()
+}
+
+// CurrentNode returns the node the cursor is at.
+func (c *TreeCursor) CurrentNode() *Node {
+	return c.tree.node(c.live().node(c.tree.live()))
+}
+
+// CurrentFieldName returns the grammar field of the current node within its
+// parent, or "" if it is in none.
+func (c *TreeCursor) CurrentFieldName() string {
+	id := c.live().fieldID()
+	if id == 0 {
+		return ""
+	}
+	return c.tree.lang.FieldName(id)
+}
+
+// Reset moves the cursor to node, which becomes its new top, so the cursor
+// can be reused for another subtree or another tree.
+func (c *TreeCursor) Reset(node *Node) {
+	c.live().reset(node.live())
+	c.tree = node.tree
+}
+
+// live returns the C cursor, panicking if c or its tree is closed.
+func (c *TreeCursor) live() *tsCursor {
+	if c.closed || c.tree.closed {
+		panic(ErrClosed)
+	}
+	return &c.cur
+}
+
+// Close frees the cursor. Further use of the cursor is not allowed; calling
+// Close again does nothing.
+func (c *TreeCursor) Close() {
+	if !c.closed {
+		c.closed = true
+		c.cur.delete()
+	}
+}
@@ -0,0 +1,115 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestTreeCursor(t *testing.T) {
+	tree := parse(t, "function f(a) { return a; }")
+	defer tree.Close()
+
+	c := tree.Walk()
+	defer c.Close()
+	if c.CurrentNode().Kind() != "source_file" {
+		t.Fatalf("cursor starts at %s, want source_file", c.CurrentNode().Kind())
+	}
+	if c.GoToParent() || c.GoToNextSibling() {
+		t.Errorf("cursor moved above or beside its root")
+	}
+	c.GoToFirstChild() // statement
+	c.GoToFirstChild() // function_declaration
+	if !c.GoToFirstChild() {
+		t.Fatal("function_declaration has no children")
+	}
+	var fields []string
+	for ok := true; ok; ok = c.GoToNextSibling() {
+		fields = append(fields, c.CurrentNode().Kind()+":"+c.CurrentFieldName())
+	}
+	if got, want := strings.Join(fields, " "), "function: identifier:name parameter_list:parameters block_statement:body"; got != want {
+		t.Errorf("children = %q, want %q", got, want)
+	}
+	if !c.GoToParent() || c.CurrentNode().Kind() != "function_declaration" {
+		t.Errorf("GoToParent did not return to function_declaration")
+	}
+
+	// Reset makes the cursor reusable for another subtree or tree.
+	other := parse(t, "var x = 1;")
+	defer other.Close()
+	decl := other.RootNode().NamedChild(0).NamedChild(0)
+	c.Reset(decl)
+	if c.CurrentNode().Kind() != "variable_declaration" || c.GoToParent() {
+		t.Errorf("Reset did not make variable_declaration the top")
+	}
+	if !c.GoToFirstChild() || !c.GoToNextSibling() || c.CurrentNode().Utf8Text() != "x" || c.CurrentFieldName() != "name" {
+		t.Errorf("cursor after Reset is at %v", c.CurrentNode())
+	}
+	c.Close()
+	c.Close()
+}
+
+// countWithCursor visits every node below the cursor's current node.
+func countWithCursor(c *grove.TreeCursor) int {
+	n := 0
+	for {
+		n++
+		if c.GoToFirstChild() {
+			continue
+		}
+		for !c.GoToNextSibling() {
+			if !c.GoToParent() {
+				return n
+			}
+		}
+	}
+}
+
+func countWithChild(n *grove.Node) int {
+	total := 1
+	for i := 0; i < n.ChildCount(); i++ {
+		total += countWithChild(n.Child(i))
+	}
+	return total
+}
+
+func TestTreeCursorVisitsEveryNode(t *testing.T) {
+	tree := parse(t, string(largeSource(20)))
+	defer tree.Close()
+	c := tree.Walk()
+	defer c.Close()
+	if got, want := countWithCursor(c), countWithChild(tree.RootNode()); got != want {
+		t.Errorf("cursor visited %d nodes, Child visited %d", got, want)
+	}
+	root := tree.RootNode()
+	if allocs := testing.AllocsPerRun(10, func() {
+		c.Reset(root)
+		countWithCursor(c)
+	}); allocs > 0 {
+		t.Errorf("cursor walk allocated %v times", allocs)
+	}
+}
+
+func BenchmarkWalkCursor(b *testing.B) {
+	tree := parse(b, string(largeSource(50000)))
+	defer tree.Close()
+	c := tree.Walk()
+	defer c.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Reset(tree.RootNode())
+		countWithCursor(c)
+	}
+}
+
+func BenchmarkWalkChild(b *testing.B) {
+	tree := parse(b, string(largeSource(50000)))
+	defer tree.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		countWithChild(tree.RootNode())
+	}
+}
@@ -0,0 +1,10 @@
+// Package grove is a high-level Go API over the tree-sitter runtime provided
+// by go-tree-sitter. It manages parser and tree lifecycles, keeps parsed
+// source alongside its tree, and hosts the language registry that grammar
+// packages such as tree-sitter-ghostlang register themselves into.
+//
+// Languages are native grammars, either linked into the binary or opened
+// from a shared library with LoadDynamicLanguage. WebAssembly grammars are
+// not supported: go-tree-sitter builds the runtime without a wasmtime engine,
+// so there is no wasm store to load them into.
+package grove
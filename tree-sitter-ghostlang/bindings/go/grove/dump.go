@@ -0,0 +1,132 @@
+package grove
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// DumpOptions controls the output of Node.Dump.
+type DumpOptions struct {
+	// IncludeAnonymous adds anonymous tokens such as "var" and ";".
+	IncludeAnonymous bool
+	// TextWidth, if positive, appends each node's source text, quoted and
+	// cut to at most TextWidth runes.
+	TextWidth int
+	// MaxDepth, if positive, stops descending below that many levels under
+	// the dumped node. Nodes whose children were left out end in "…".
+	MaxDepth int
+	// Color highlights field names, kinds, errors and text with ANSI escape
+	// sequences for display in a terminal.
+	Color bool
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiDim   = "\x1b[2m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// Dump writes the node and its descendants to w, one node per line,
+// indented two spaces per level:
+//
+//	variable_declaration [0, 10)
+//	  name: identifier [4, 5)
+//	  value: expression [8, 9)
+//
+// Each line holds the node's field name within its parent, its kind and its
+// byte range. The tree is walked with a cursor, so deep trees do not grow the
+// stack. Dump returns the first error from w.
+func (n *Node) Dump(w io.Writer, opts DumpOptions) error {
+	c := sitter.NewTreeCursor(n.live())
+	defer c.Close()
+
+	var line []byte
+	depth := 0 // depth of the cursor below n
+	for {
+		node := c.CurrentNode()
+		descend := opts.MaxDepth <= 0 || depth < opts.MaxDepth
+		if node.IsNamed() || node.IsMissing() || opts.IncludeAnonymous {
+			line = appendDumpLine(line[:0], n.tree, node, c.CurrentFieldName(), depth, opts)
+			if !descend && node.ChildCount() > 0 {
+				line = append(line, " …"...)
+			}
+			line = append(line, '\n')
+			if _, err := w.Write(line); err != nil {
+				return err
+			}
+		}
+		if descend && c.GoToFirstChild() {
+			depth++
+			continue
+		}
+		for !c.GoToNextSibling() {
+			if !c.GoToParent() {
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+func appendDumpLine(b []byte, tree *Tree, n *sitter.Node, field string, depth int, opts DumpOptions) []byte {
+	color := func(b []byte, code, s string) []byte {
+		if !opts.Color {
+			return append(b, s...)
+		}
+		b = append(b, code...)
+		b = append(b, s...)
+		return append(b, ansiReset...)
+	}
+	for i := 0; i < depth; i++ {
+		b = append(b, "  "...)
+	}
+	if field != "" && depth > 0 {
+		b = color(b, ansiCyan, field+":")
+		b = append(b, ' ')
+	}
+	kind := n.Type()
+	if !n.IsNamed() {
+		kind = strconv.Quote(kind)
+	}
+	switch {
+	case n.IsMissing():
+		b = color(b, ansiRed, "MISSING "+kind)
+	case n.IsError():
+		b = color(b, ansiRed, kind)
+	case n.IsNamed():
+		b = color(b, ansiBold, kind)
+	default:
+		b = append(b, kind...)
+	}
+	b = append(b, ' ')
+	b = color(b, ansiDim, fmt.Sprintf("[%d, %d)", n.StartByte(), n.EndByte()))
+	if opts.TextWidth > 0 {
+		// An edited tree not yet reparsed may extend past its source.
+		end := min(n.EndByte(), uint32(len(tree.src)))
+		start := min(n.StartByte(), end)
+		b = append(b, ' ')
+		b = color(b, ansiGreen, strconv.Quote(truncateRunes(tree.text(start, end), opts.TextWidth)))
+	}
+	return b
+}
+
+// truncateRunes returns text cut to at most width runes, ending in "…" when
+// anything was cut.
+func truncateRunes(text []byte, width int) string {
+	if utf8.RuneCount(text) <= width {
+		return string(text)
+	}
+	end := 0
+	for i := 0; i < width-1; i++ {
+		_, size := utf8.DecodeRune(text[end:])
+		end += size
+	}
+	return string(text[:end]) + "…"
+}
@@ -0,0 +1,129 @@
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestDump(t *testing.T) {
+	tree := parse(t, "var x = \"hello world\";")
+	defer tree.Close()
+	decl := tree.RootNode().NamedChild(0).NamedChild(0)
+
+	var b strings.Builder
+	if err := decl.Dump(&b, grove.DumpOptions{TextWidth: 6, MaxDepth: 2}); err != nil {
+		t.Fatal(err)
+	}
+	want := "variable_declaration [0, 22) \"var x…\"\n" +
+		"  name: identifier [4, 5) \"x\"\n" +
+		"  value: expression [8, 21) \"\\\"hell…\"\n" +
+		"    conditional_expression [8, 21) \"\\\"hell…\" …\n"
+	if b.String() != want {
+		t.Errorf("Dump =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestDumpIncludedRanges(t *testing.T) {
+	src := "<% var a = 1; %>\n<p>gap</p>\n<% var b = 2; %>\n"
+	var ranges []grove.Range
+	for _, code := range []string{"var a = 1;", "var b = 2;"} {
+		start := strings.Index(src, code)
+		end := start + len(code)
+		ranges = append(ranges, grove.Range{StartByte: uint32(start), EndByte: uint32(end), StartPoint: pointAt(src, start), EndPoint: pointAt(src, end)})
+	}
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	if err := p.SetIncludedRanges(ranges); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+
+	var b strings.Builder
+	if err := tree.RootNode().Dump(&b, grove.DumpOptions{TextWidth: 40, MaxDepth: 1}); err != nil {
+		t.Fatal(err)
+	}
+	first, _, _ := strings.Cut(b.String(), "\n")
+	if !strings.HasSuffix(first, `"var a = 1;var b = 2;"`) {
+		t.Errorf("root line %q does not show only the included text", first)
+	}
+}
+
+func TestDumpEditedTree(t *testing.T) {
+	src := "var x = 1;"
+	tree := parse(t, src)
+	defer tree.Close()
+	_, edit := replace(src, 8, 9, "12345")
+	tree.Edit(edit)
+
+	// The edited tree extends past the source it was parsed from.
+	var b strings.Builder
+	if err := tree.RootNode().Dump(&b, grove.DumpOptions{TextWidth: 20}); err != nil {
+		t.Fatal(err)
+	}
+	if first, _, _ := strings.Cut(b.String(), "\n"); first != "source_file [0, 14) \"var x = 1;\"" {
+		t.Errorf("root line of the edited tree = %q", first)
+	}
+}
+
+func TestDumpAnonymousAndColor(t *testing.T) {
+	tree := parse(t, "var x = 1")
+	defer tree.Close()
+
+	var b strings.Builder
+	if err := tree.RootNode().Dump(&b, grove.DumpOptions{IncludeAnonymous: true, Color: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"\"var\"", "\x1b[31mMISSING \";\"\x1b[0m", "\x1b[36mname:\x1b[0m"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Dump output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDumpDeepTree(t *testing.T) {
+	// Each parenthesised expression nests a dozen levels of precedence
+	// rules, far deeper than a recursive dump would comfortably go.
+	src := "var x = " + strings.Repeat("(", 2000) + "1" + strings.Repeat(")", 2000) + ";"
+	tree := parse(t, src)
+	defer tree.Close()
+	var lines int
+	err := tree.RootNode().Dump(writerFunc(func(p []byte) (int, error) {
+		lines++
+		return len(p), nil
+	}), grove.DumpOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines < 20000 {
+		t.Errorf("Dump wrote %d lines for a deeply nested tree", lines)
+	}
+}
+
+func TestDumpWriteError(t *testing.T) {
+	tree := parse(t, "var x = 1;")
+	defer tree.Close()
+	errFull := errors.New("full")
+	calls := 0
+	err := tree.RootNode().Dump(writerFunc(func(p []byte) (int, error) {
+		calls++
+		return 0, errFull
+	}), grove.DumpOptions{})
+	if !errors.Is(err, errFull) || calls != 1 {
+		t.Errorf("Dump = %v after %d writes, want the writer's error after 1", err, calls)
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -0,0 +1,78 @@
+//go:build (linux || darwin) && !grove_nodlopen
+
+package grove
+
+// #cgo linux LDFLAGS: -ldl
+// #include <dlfcn.h>
+// #include <stdlib.h>
+//
+// static const void *grove_call_language(void *fn) {
+// 	return ((const void *(*)(void))fn)();
+// }
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// dynamicLibrary is a shared library opened by LoadDynamicLanguage.
+type dynamicLibrary struct {
+	once   sync.Once
+	handle unsafe.Pointer
+}
+
+func (lib *dynamicLibrary) close() error {
+	var err error
+	lib.once.Do(func() {
+		if C.dlclose(lib.handle) != 0 {
+			err = fmt.Errorf("grove: dlclose: %s", dlerror())
+		}
+	})
+	return err
+}
+
+func dlerror() string {
+	if msg := C.dlerror(); msg != nil {
+		return C.GoString(msg)
+	}
+	return "unknown error"
+}
+
+// LoadDynamicLanguage opens the shared library at path and returns the
+// grammar from its symbol entry point, such as "tree_sitter_ghostlang". The
+// language is named after symbol without its "tree_sitter_" prefix. It fails
+// if the library cannot be opened, lacks symbol, or holds a grammar that
+// CheckCompatible rejects. Call the language's Close to unload the library.
+//
+// Dynamic loading is available on Linux and macOS and can be compiled out
+// with the grove_nodlopen build tag.
+func LoadDynamicLanguage(path, symbol string) (*Language, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	handle := C.dlopen(cpath, C.RTLD_NOW|C.RTLD_LOCAL)
+	if handle == nil {
+		return nil, fmt.Errorf("grove: cannot open grammar library: %s", dlerror())
+	}
+	lib := &dynamicLibrary{handle: handle}
+
+	csym := C.CString(symbol)
+	defer C.free(unsafe.Pointer(csym))
+	C.dlerror()
+	fn := C.dlsym(handle, csym)
+	if fn == nil {
+		err := fmt.Errorf("grove: grammar library %s has no symbol %s: %s", path, symbol, dlerror())
+		lib.close()
+		return nil, err
+	}
+
+	lang, err := NewLanguage(strings.TrimPrefix(symbol, "tree_sitter_"), unsafe.Pointer(C.grove_call_language(fn)))
+	if err != nil {
+		lib.close()
+		return nil, err
+	}
+	lang.lib = lib
+	return lang, nil
+}
@@ -0,0 +1,18 @@
+//go:build !(linux || darwin) || grove_nodlopen
+
+package grove
+
+import "errors"
+
+type dynamicLibrary struct{}
+
+func (*dynamicLibrary) close() error {
+	return nil
+}
+
+// LoadDynamicLanguage loads a grammar from a shared library on Linux and
+// macOS. In this build it always fails: the platform has no dlopen, or the
+// grove_nodlopen build tag compiled it out.
+func LoadDynamicLanguage(path, symbol string) (*Language, error) {
+	return nil, errors.New("grove: dynamic grammar loading is not available in this build")
+}
@@ -0,0 +1,120 @@
+//go:build (linux || darwin) && !grove_nodlopen
+
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// buildGrammarLibrary compiles the Ghostlang parser into a shared library.
+func buildGrammarLibrary(t *testing.T) string {
+	t.Helper()
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler to build a grammar library")
+	}
+	lib := filepath.Join(t.TempDir(), "libtree-sitter-ghostlang.so")
+	out, err := exec.Command(cc, "-shared", "-fPIC", "-O0", "-I", "../../../src", "-o", lib, "../../../src/parser.c").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building grammar library: %v\n%s", err, out)
+	}
+	return lib
+}
+
+func TestLoadDynamicLanguage(t *testing.T) {
+	lib := buildGrammarLibrary(t)
+
+	lang, err := grove.LoadDynamicLanguage(lib, "tree_sitter_ghostlang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang.Name() != "ghostlang" {
+		t.Errorf("Name() = %q, want ghostlang", lang.Name())
+	}
+	p := newParser(t, lang)
+	tree, err := p.Parse(context.Background(), []byte("var x = 1;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	static := parse(t, "var x = 1;")
+	defer static.Close()
+	if got, want := tree.String(), static.String(); got != want {
+		t.Errorf("dynamic grammar parsed %s, want %s", got, want)
+	}
+	tree.Close()
+	p.Close()
+	if err := lang.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if err := lang.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	if _, err := grove.LoadDynamicLanguage(lib, "tree_sitter_nope"); err == nil || !strings.Contains(err.Error(), "tree_sitter_nope") {
+		t.Errorf("missing symbol: err = %v", err)
+	}
+	if _, err := grove.LoadDynamicLanguage(filepath.Join(t.TempDir(), "missing.so"), "tree_sitter_ghostlang"); err == nil {
+		t.Errorf("missing library: no error")
+	}
+}
+
+func TestLoadDynamicLanguageReloadQueryCache(t *testing.T) {
+	lib := buildGrammarLibrary(t)
+	const source = "(identifier) @name"
+
+	l1, err := grove.LoadDynamicLanguage(lib, "tree_sitter_ghostlang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q1, err := grove.NewQuery(l1, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q1.Close()
+	if err := l1.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	l2, err := grove.LoadDynamicLanguage(lib, "tree_sitter_ghostlang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l2.Close()
+	q2, err := grove.NewQuery(l2, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q2.Close()
+	if q2.Language() != l2 {
+		t.Errorf("query for the reloaded language belongs to the closed one")
+	}
+}
+
+func TestLoadDynamicLanguageIncompatible(t *testing.T) {
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler to build a grammar library")
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "future.c")
+	lib := filepath.Join(dir, "libfuture.so")
+	// A TSLanguage starts with its ABI version.
+	code := "static const unsigned versions[64] = {99};\nconst void *tree_sitter_future(void) { return versions; }\n"
+	if err := os.WriteFile(src, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command(cc, "-shared", "-fPIC", "-o", lib, src).CombinedOutput(); err != nil {
+		t.Fatalf("building library: %v\n%s", err, out)
+	}
+	if _, err := grove.LoadDynamicLanguage(lib, "tree_sitter_future"); !errors.Is(err, grove.ErrIncompatibleLanguage) {
+		t.Errorf("LoadDynamicLanguage(future) = %v, want ErrIncompatibleLanguage", err)
+	}
+}
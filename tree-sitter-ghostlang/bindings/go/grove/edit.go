@@ -0,0 +1,70 @@
+package grove
+
+// InputEdit describes a single replacement in source text: the bytes in
+// [StartByte, OldEndByte) were replaced by the bytes now in
+// [StartByte, NewEndByte). Points carry the same positions as rows and byte
+// columns, whatever ColumnEncoding the tree uses.
+type InputEdit struct {
+	StartByte   uint32
+	OldEndByte  uint32
+	NewEndByte  uint32
+	StartPoint  Point
+	OldEndPoint Point
+	NewEndPoint Point
+}
+
+// MapOffset translates a byte offset in the text before the edit to the
+// text after it. Offsets up to and including StartByte stay where they are,
+// so an offset at an insertion point stays before the inserted text.
+// Offsets strictly inside the replaced bytes clamp to NewEndByte, and
+// offsets from OldEndByte on shift by the change in length, whether the
+// edit grew or shrank the text.
+func (e InputEdit) MapOffset(old uint32) uint32 {
+	switch {
+	case old <= e.StartByte:
+		return old
+	case old < e.OldEndByte:
+		return e.NewEndByte
+	default:
+		return old - e.OldEndByte + e.NewEndByte
+	}
+}
+
+// MapPoint is MapOffset for row/column positions. A point after the edit on
+// the row where the replaced text ended moves to the row and column where the
+// new text ends, plus its distance from the old end; a point on a later row
+// only changes row. Columns are taken to be in the same unit as the edit's
+// points.
+func (e InputEdit) MapPoint(old Point) Point {
+	switch {
+	case !e.StartPoint.less(old):
+		return old
+	case old.less(e.OldEndPoint):
+		return e.NewEndPoint
+	case old.Row == e.OldEndPoint.Row:
+		return Point{Row: e.NewEndPoint.Row, Column: e.NewEndPoint.Column + old.Column - e.OldEndPoint.Column}
+	default:
+		return Point{Row: old.Row - e.OldEndPoint.Row + e.NewEndPoint.Row, Column: old.Column}
+	}
+}
+
+// less reports whether p comes before q.
+func (p Point) less(q Point) bool {
+	return p.Row < q.Row || p.Row == q.Row && p.Column < q.Column
+}
+
+// Edit adjusts the tree for an edit to its source so that it can be passed to
+// Parser.ParseIncremental. Call it once per edit, in the order the edits were
+// made to the text. Node positions in the edited tree are only approximate
+// until it has been re-parsed.
+func (t *Tree) Edit(edit InputEdit) {
+	tsTreeEdit(t.live(), edit)
+}
+
+// ChangedRanges returns the ranges of new whose syntactic structure differs
+// from old, where old is the edited tree that new was parsed from with
+// Parser.ParseIncremental. Text that was edited without changing the shape
+// of the tree, such as a replaced identifier, is not reported.
+func ChangedRanges(old, new *Tree) []Range {
+	return tsChangedRanges(old.live(), new.live())
+}
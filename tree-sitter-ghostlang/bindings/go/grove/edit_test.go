@@ -0,0 +1,212 @@
+package grove_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// replace applies src[start:end] = text and returns the new source with the
+// matching edit. Points are computed from the text, with byte columns.
+func replace(src string, start, end int, text string) (string, grove.InputEdit) {
+	next := src[:start] + text + src[end:]
+	point := func(s string, off int) grove.Point {
+		row := strings.Count(s[:off], "\n")
+		col := off - (strings.LastIndex(s[:off], "\n") + 1)
+		return grove.Point{Row: uint32(row), Column: uint32(col)}
+	}
+	return next, grove.InputEdit{
+		StartByte:   uint32(start),
+		OldEndByte:  uint32(end),
+		NewEndByte:  uint32(start + len(text)),
+		StartPoint:  point(src, start),
+		OldEndPoint: point(src, end),
+		NewEndPoint: point(next, start+len(text)),
+	}
+}
+
+func reparse(t *testing.T, p *grove.Parser, old *grove.Tree, src string) *grove.Tree {
+	t.Helper()
+	tree, err := p.ParseIncremental(context.Background(), old, []byte(src))
+	if err != nil {
+		t.Fatalf("ParseIncremental: %v", err)
+	}
+	return tree
+}
+
+func TestEditKeepsUnchangedSubtrees(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	src := "function a() { return 1; }\nfunction b() { return 2; }\n"
+	old, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Statement wrappers are re-reduced; the declarations inside are reused.
+	decl := func(tree *grove.Tree, i int) *grove.Node {
+		return tree.RootNode().NamedChild(i).NamedChild(0)
+	}
+	idBefore := decl(old, 0).ID()
+
+	at := strings.Index(src, "2")
+	next, edit := replace(src, at, at+1, "42")
+	old.Edit(edit)
+	tree := reparse(t, p, old, next)
+
+	if decl(tree, 0).ID() != idBefore {
+		t.Errorf("unchanged function a was not reused")
+	}
+	if decl(tree, 1).ID() == decl(old, 1).ID() {
+		t.Errorf("edited function b was reused")
+	}
+	if got := tree.RootNode().EndByte(); got != uint32(len(next)) {
+		t.Errorf("root ends at %d, want %d", got, len(next))
+	}
+}
+
+func TestEditMultiByte(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	src := "var s = \"a\";\nvar n = 1;\n"
+	old, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := strings.Index(src, `"a"`) + 1
+	next, edit := replace(src, at, at+1, "héllo\nwörld")
+	old.Edit(edit)
+	tree := reparse(t, p, old, next)
+
+	if n := tree.RootNode().NamedChildCount(); n != 2 {
+		t.Fatalf("root has %d statements after edit, want 2", n)
+	}
+	last := tree.RootNode().NamedChild(1)
+	want := grove.Point{Row: 2, Column: 0}
+	if got := last.StartPoint(); got != want {
+		t.Errorf("second declaration starts at %+v, want %+v", got, want)
+	}
+	if got := last.StartByte(); got != uint32(strings.Index(next, "var n")) {
+		t.Errorf("second declaration starts at byte %d", got)
+	}
+}
+
+func TestEditAtEndOfBuffer(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	src := "var x = 1;"
+	old, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An empty edit at the very end has old-end == new-end == len(src).
+	_, edit := replace(src, len(src), len(src), "")
+	old.Edit(edit)
+	same := reparse(t, p, old, src)
+	if n := same.RootNode().NamedChildCount(); n != 1 {
+		t.Errorf("root has %d statements after empty edit, want 1", n)
+	}
+	if got := same.RootNode().EndByte(); got != uint32(len(src)) {
+		t.Errorf("root ends at %d after empty edit, want %d", got, len(src))
+	}
+
+	next, edit := replace(src, len(src), len(src), "\nvar y = 2;")
+	same.Edit(edit)
+	tree := reparse(t, p, same, next)
+	if n := tree.RootNode().NamedChildCount(); n != 2 {
+		t.Errorf("root has %d statements after append, want 2", n)
+	}
+}
+
+func TestChangedRanges(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	src := "function a() { return 1; }\nfunction b() {\n  return 2;\n}\nfunction c() { return 3; }\n"
+	fnB := [2]uint32{uint32(strings.Index(src, "function b")), uint32(strings.Index(src, "}\nfunction c") + 1)}
+	for _, tt := range []struct {
+		name       string
+		old, text  string
+		mustChange string
+	}{
+		{"edit in function", "2", "f(2)", "f(2)"},
+		{"inserted line", "  return", "  var z = 3;\n  return", "var z = 3;"},
+	} {
+		old, err := p.Parse(context.Background(), []byte(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		at := strings.Index(src, tt.old)
+		next, edit := replace(src, at, at+len(tt.old), tt.text)
+		old.Edit(edit)
+		tree := reparse(t, p, old, next)
+
+		ranges := grove.ChangedRanges(old, tree)
+		if len(ranges) == 0 {
+			t.Errorf("%s: no changed ranges", tt.name)
+			continue
+		}
+		grown := edit.NewEndByte - edit.OldEndByte
+		start, end := ranges[0].StartByte, ranges[len(ranges)-1].EndByte
+		if start < fnB[0] || end > fnB[1]+grown {
+			t.Errorf("%s: changes span [%d, %d), beyond function b at [%d, %d)", tt.name, start, end, fnB[0], fnB[1]+grown)
+		}
+		want := uint32(strings.Index(next, tt.mustChange))
+		if start > want || end < want+uint32(len(tt.mustChange)) {
+			t.Errorf("%s: changes span [%d, %d), want them to cover %q at %d", tt.name, start, end, tt.mustChange, want)
+		}
+		old.Close()
+		tree.Close()
+	}
+}
+
+func TestInputEditMapOffset(t *testing.T) {
+	src := "var a = 1;\nvar b = 2;\n"
+	at := strings.Index(src, "1")
+	for _, tt := range []struct {
+		name       string
+		start, end int
+		text       string
+	}{
+		{"replace", at, at + 1, "f(1)"},
+		{"insert", at, at, "1 + "},
+		{"delete", at - 2, at + 1, ""},
+		{"multi-line", at, at + 1, "{\n  x: 1\n}"},
+		{"join lines", strings.Index(src, ";\n") + 1, strings.Index(src, "var b"), " "},
+	} {
+		next, edit := replace(src, tt.start, tt.end, tt.text)
+		// An offset up to the edit keeps the text before it, one after the
+		// edit keeps the text after it, and its point follows along.
+		for old := 0; old <= len(src); old++ {
+			got := edit.MapOffset(uint32(old))
+			switch {
+			case old <= tt.start:
+				if src[:old] != next[:got] {
+					t.Errorf("%s: offset %d before the edit maps to %d", tt.name, old, got)
+				}
+			case old < tt.end:
+				if got != edit.NewEndByte {
+					t.Errorf("%s: offset %d inside the edit maps to %d, want %d", tt.name, old, got, edit.NewEndByte)
+				}
+			default:
+				if src[old:] != next[got:] {
+					t.Errorf("%s: offset %d after the edit maps to %d", tt.name, old, got)
+				}
+			}
+			wantPoint := pointAt(next, int(got))
+			if gotPoint := edit.MapPoint(pointAt(src, old)); gotPoint != wantPoint {
+				t.Errorf("%s: point of offset %d maps to %+v, want %+v", tt.name, old, gotPoint, wantPoint)
+			}
+		}
+	}
+}
+
+func pointAt(s string, off int) grove.Point {
+	row := strings.Count(s[:off], "\n")
+	return grove.Point{Row: uint32(row), Column: uint32(off - (strings.LastIndex(s[:off], "\n") + 1))}
+}
@@ -0,0 +1,165 @@
+package grove
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrClosed reports the use of a Tree, Parser, Query or TreeCursor after its
+// Close method was called. Methods that return errors return it; others
+// panic with it, since the C memory behind them has been freed.
+var ErrClosed = errors.New("grove: use of closed object")
+
+// SyntaxErrorKind distinguishes the two ways tree-sitter records a syntax
+// error.
+type SyntaxErrorKind int
+
+const (
+	// ErrorNode is text the parser could not fit into the grammar.
+	ErrorNode SyntaxErrorKind = iota
+	// MissingNode is a zero-width token the parser inserted to recover.
+	MissingNode
+)
+
+func (k SyntaxErrorKind) String() string {
+	if k == MissingNode {
+		return "missing"
+	}
+	return "error"
+}
+
+// SyntaxError is an ERROR or MISSING node found in a tree.
+type SyntaxError struct {
+	Kind       SyntaxErrorKind
+	StartByte  uint32
+	EndByte    uint32
+	StartPoint Point
+	EndPoint   Point
+	// Expected is the kind of the token a MissingNode stands in for, such as
+	// ";" or "identifier". It is empty for an ErrorNode, unless the error
+	// was collapsed with missing nodes next to it.
+	Expected string
+}
+
+func (e SyntaxError) Error() string {
+	if e.Kind == MissingNode {
+		return fmt.Sprintf("%d:%d: missing %s", e.StartPoint.Row+1, e.StartPoint.Column+1, e.Expected)
+	}
+	if e.Expected != "" {
+		return fmt.Sprintf("%d:%d: syntax error, expected %s", e.StartPoint.Row+1, e.StartPoint.Column+1, e.Expected)
+	}
+	return fmt.Sprintf("%d:%d: syntax error", e.StartPoint.Row+1, e.StartPoint.Column+1)
+}
+
+// ErrorOptions tidies the syntax errors returned by ErrorsWithOptions for
+// display. The zero value changes nothing, as for Errors.
+type ErrorOptions struct {
+	// CollapseAdjacent merges errors whose ranges touch or overlap into one
+	// spanning them all. The merged error is an ErrorNode if any of them
+	// was, and keeps the Expected kind of the first missing node among them.
+	CollapseAdjacent bool
+	// DropZeroWidth leaves out errors that cover no text, which are missing
+	// nodes. With CollapseAdjacent, a missing node next to another error is
+	// merged into it first, so its Expected kind is kept.
+	DropZeroWidth bool
+	// MaxErrors, if positive, keeps only the first MaxErrors errors.
+	MaxErrors int
+}
+
+// EditorErrorOptions are the ErrorOptions suited to showing diagnostics in
+// an editor: each cascade of touching errors is reported once, and at most
+// 100 errors are reported. Missing nodes on their own, such as a missing
+// ";", are kept. Use Errors for the raw output when debugging a grammar.
+var EditorErrorOptions = ErrorOptions{CollapseAdjacent: true, MaxErrors: 100}
+
+// ErrorsWithOptions is like Errors, with the errors merged, filtered and
+// capped as opts asks.
+func (t *Tree) ErrorsWithOptions(opts ErrorOptions) []SyntaxError {
+	errs := t.Errors()
+	if opts.CollapseAdjacent {
+		errs = collapseErrors(errs)
+	}
+	if opts.DropZeroWidth {
+		kept := errs[:0]
+		for _, e := range errs {
+			if e.StartByte != e.EndByte {
+				kept = append(kept, e)
+			}
+		}
+		errs = kept
+	}
+	if opts.MaxErrors > 0 && len(errs) > opts.MaxErrors {
+		errs = errs[:opts.MaxErrors]
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// collapseErrors merges runs of touching or overlapping errors, which
+// Errors returns in source order.
+func collapseErrors(errs []SyntaxError) []SyntaxError {
+	var merged []SyntaxError
+	for _, e := range errs {
+		n := len(merged)
+		if n == 0 || e.StartByte > merged[n-1].EndByte {
+			merged = append(merged, e)
+			continue
+		}
+		last := &merged[n-1]
+		if e.EndByte > last.EndByte {
+			last.EndByte, last.EndPoint = e.EndByte, e.EndPoint
+		}
+		if e.Kind == ErrorNode {
+			last.Kind = ErrorNode
+		}
+		if last.Expected == "" {
+			last.Expected = e.Expected
+		}
+	}
+	return merged
+}
+
+// HasError reports whether the tree contains any syntax errors. It only
+// checks a flag on the root node.
+func (t *Tree) HasError() bool {
+	return t.live().RootNode().HasError()
+}
+
+// Errors returns the tree's syntax errors in source order. An ERROR node is
+// reported once as a whole; errors nested inside it are not reported
+// separately, so an unterminated string running to the end of the file is a
+// single error.
+func (t *Tree) Errors() []SyntaxError {
+	if !t.HasError() {
+		return nil
+	}
+	var errs []SyntaxError
+	Walk(t.RootNode(), func(n *Node) bool {
+		switch {
+		case n.IsError():
+			errs = append(errs, newSyntaxError(n, ErrorNode))
+			return false
+		case n.IsMissing():
+			errs = append(errs, newSyntaxError(n, MissingNode))
+			return false
+		}
+		return n.HasError()
+	})
+	return errs
+}
+
+func newSyntaxError(n *Node, kind SyntaxErrorKind) SyntaxError {
+	e := SyntaxError{
+		Kind:       kind,
+		StartByte:  n.StartByte(),
+		EndByte:    n.EndByte(),
+		StartPoint: n.StartPoint(),
+		EndPoint:   n.EndPoint(),
+	}
+	if kind == MissingNode {
+		e.Expected = n.Kind()
+	}
+	return e
+}
@@ -0,0 +1,108 @@
+package grove_test
+
+import (
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestErrors(t *testing.T) {
+	tree := parse(t, "var x = 1;\n")
+	if tree.HasError() || tree.Errors() != nil {
+		t.Errorf("valid source reported errors: %v", tree.Errors())
+	}
+
+	tree = parse(t, "function f( {\n}\nvar y = ;")
+	if !tree.HasError() {
+		t.Fatalf("HasError() = false for broken source")
+	}
+	errs := tree.Errors()
+	want := []grove.SyntaxError{
+		{Kind: grove.MissingNode, StartByte: 12, EndByte: 12, StartPoint: grove.Point{Row: 0, Column: 12}, EndPoint: grove.Point{Row: 0, Column: 12}, Expected: ")"},
+		{Kind: grove.MissingNode, StartByte: 24, EndByte: 24, StartPoint: grove.Point{Row: 2, Column: 8}, EndPoint: grove.Point{Row: 2, Column: 8}, Expected: "identifier"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Errors() = %v, want %d errors", errs, len(want))
+	}
+	for i := range want {
+		if errs[i] != want[i] {
+			t.Errorf("error %d = %#v, want %#v", i, errs[i], want[i])
+		}
+	}
+	if got := errs[0].Error(); got != "1:13: missing )" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestErrorsUnterminatedString(t *testing.T) {
+	src := "var s = \"abc;\nvar y = 1;\nfunction f() { return 2; }\n"
+	tree := parse(t, src)
+	errs := tree.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("Errors() = %v, want a single error", errs)
+	}
+	e := errs[0]
+	if e.Kind != grove.ErrorNode || e.StartByte != 0 || e.EndByte != uint32(len(src)) {
+		t.Errorf("error = %#v, want an ErrorNode spanning the rest of the file", e)
+	}
+	if e.EndPoint != (grove.Point{Row: 3, Column: 0}) {
+		t.Errorf("error ends at %+v", e.EndPoint)
+	}
+}
+
+func TestErrorsWithOptions(t *testing.T) {
+	// A stray ")" and, at the end, a missing ";" right where an error
+	// region around "f ." starts.
+	tree := parse(t, "return ) ; f f . ")
+	defer tree.Close()
+	raw := tree.Errors()
+	if len(raw) != 3 || raw[1].Kind != grove.MissingNode || raw[1].StartByte != raw[2].StartByte {
+		t.Fatalf("Errors() = %#v, want an error then a missing node touching another error", raw)
+	}
+	if got := tree.ErrorsWithOptions(grove.ErrorOptions{}); len(got) != len(raw) {
+		t.Errorf("zero ErrorOptions changed the errors: %v", got)
+	}
+
+	collapsed := tree.ErrorsWithOptions(grove.ErrorOptions{CollapseAdjacent: true})
+	if len(collapsed) != 2 {
+		t.Fatalf("collapsed errors = %v, want 2", collapsed)
+	}
+	want := raw[2]
+	want.Expected = ";"
+	if collapsed[0] != raw[0] || collapsed[1] != want {
+		t.Errorf("collapsed errors = %#v, want %#v and %#v", collapsed, raw[0], want)
+	}
+	if got := collapsed[1].Error(); got != "1:14: syntax error, expected ;" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	// Without collapsing, the zero-width missing node is simply dropped.
+	dropped := tree.ErrorsWithOptions(grove.ErrorOptions{DropZeroWidth: true})
+	if len(dropped) != 2 || dropped[0] != raw[0] || dropped[1] != raw[2] {
+		t.Errorf("errors without zero-width ones = %v", dropped)
+	}
+	both := tree.ErrorsWithOptions(grove.ErrorOptions{CollapseAdjacent: true, DropZeroWidth: true})
+	if len(both) != 2 || both[1].Expected != ";" {
+		t.Errorf("collapsed errors without zero-width ones = %v", both)
+	}
+
+	if editor := tree.ErrorsWithOptions(grove.EditorErrorOptions); len(editor) != 2 || editor[1] != want {
+		t.Errorf("errors with EditorErrorOptions = %v, want them collapsed", editor)
+	}
+
+	capped := tree.ErrorsWithOptions(grove.ErrorOptions{MaxErrors: 1})
+	if len(capped) != 1 || capped[0] != raw[0] {
+		t.Errorf("capped errors = %v, want just the first", capped)
+	}
+}
+
+func TestErrorsWithOptionsAllDropped(t *testing.T) {
+	tree := parse(t, "var x = 1 var y = 2")
+	defer tree.Close()
+	if len(tree.Errors()) != 2 {
+		t.Fatalf("Errors() = %v, want two missing semicolons", tree.Errors())
+	}
+	if got := tree.ErrorsWithOptions(grove.ErrorOptions{DropZeroWidth: true}); got != nil {
+		t.Errorf("ErrorsWithOptions(DropZeroWidth) = %v, want nil", got)
+	}
+}
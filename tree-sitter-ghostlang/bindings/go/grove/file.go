@@ -0,0 +1,212 @@
+package grove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownLanguage is returned, wrapped, by ParseFile for a file whose
+// language the registry cannot tell.
+var ErrUnknownLanguage = errors.New("grove: unknown language")
+
+// ParseFile parses src, the contents of filename, in the language that
+// DefaultRegistry detects from the file's extension or, for a file without
+// a registered extension, its "#!" line. It returns the tree along with that
+// language, or an error wrapping ErrUnknownLanguage if none is registered.
+func ParseFile(ctx context.Context, filename string, src []byte) (*Tree, *Language, error) {
+	return DefaultRegistry.ParseFile(ctx, filename, src)
+}
+
+// ParseFile is like the package-level ParseFile but consults r.
+func (r *Registry) ParseFile(ctx context.Context, filename string, src []byte) (*Tree, *Language, error) {
+	lang, ok := r.Detect(filename, src)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w for %s", ErrUnknownLanguage, filename)
+	}
+	p, err := NewParser(lang)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.Close()
+	tree, err := p.Parse(ctx, src)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree, lang, nil
+}
+
+// ParseDirOptions controls ParseDir.
+type ParseDirOptions struct {
+	// Workers is the number of files parsed at once. Zero means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// Registry picks each file's language by extension. Nil means
+	// DefaultRegistry.
+	Registry *Registry
+	// Include, if not empty, limits the walk to files matching one of its
+	// patterns. Ignore skips matching files, and matching directories with
+	// everything below them. Patterns use path.Match syntax and are matched
+	// against the slash-separated path relative to root, or against the
+	// base name if they contain no slash, so "*_test.gza" and "vendor"
+	// match at any depth.
+	Include []string
+	Ignore  []string
+}
+
+// ParseResult is the outcome of parsing one file in ParseDir. Tree is nil
+// when Err is set; otherwise the caller owns Tree and should Close it.
+type ParseResult struct {
+	Path     string
+	Language *Language
+	Tree     *Tree
+	Err      error
+}
+
+// ParseDir walks the directory tree under root and parses every file whose
+// extension has a registered language, on opts.Workers goroutines sharing a
+// ParserPool per language. Results arrive on the returned channel as files
+// finish, so not in walk order, and the channel is closed once every file is
+// done. A file that cannot be read, or whose parse fails, yields a result
+// with Err set and does not stop the others; so does a directory that
+// cannot be listed.
+//
+// Cancelling ctx stops the walk and the parses in flight; results not yet
+// delivered are dropped and the channel is closed once the workers have
+// stopped. The caller must either receive until the channel is closed or
+// cancel ctx. ParseDir itself fails only if root is not a directory.
+func ParseDir(ctx context.Context, root string, opts ParseDirOptions) (<-chan ParseResult, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("grove: %s is not a directory", root)
+	}
+	if opts.Registry == nil {
+		opts.Registry = DefaultRegistry
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	type file struct {
+		path string
+		lang *Language
+	}
+	files := make(chan file)
+	results := make(chan ParseResult)
+	// deliver sends r unless ctx is done, in which case r is dropped.
+	deliver := func(r ParseResult) {
+		select {
+		case results <- r:
+		case <-ctx.Done():
+			if r.Tree != nil {
+				r.Tree.Close()
+			}
+		}
+	}
+
+	go func() {
+		defer close(files)
+		filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if ctx.Err() != nil {
+				return filepath.SkipAll
+			}
+			if err != nil {
+				deliver(ParseResult{Path: p, Err: err})
+				return nil
+			}
+			rel, _ := filepath.Rel(root, p)
+			rel = filepath.ToSlash(rel)
+			if rel == "." {
+				return nil
+			}
+			if matchAny(opts.Ignore, rel) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || len(opts.Include) > 0 && !matchAny(opts.Include, rel) {
+				return nil
+			}
+			lang, ok := opts.Registry.ForFilename(p)
+			if !ok {
+				return nil
+			}
+			select {
+			case files <- file{p, lang}:
+			case <-ctx.Done():
+				return filepath.SkipAll
+			}
+			return nil
+		})
+	}()
+
+	var mu sync.Mutex
+	pools := make(map[*Language]*ParserPool)
+	pool := func(lang *Language) *ParserPool {
+		mu.Lock()
+		defer mu.Unlock()
+		if pools[lang] == nil {
+			pools[lang] = NewParserPool(lang, opts.Workers)
+		}
+		return pools[lang]
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range files {
+				tree, err := parseFileWith(ctx, pool(f.lang), f.path)
+				deliver(ParseResult{Path: f.path, Language: f.lang, Tree: tree, Err: err})
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		for _, p := range pools {
+			p.Close()
+		}
+		close(results)
+	}()
+	return results, nil
+}
+
+func parseFileWith(ctx context.Context, pool *ParserPool, name string) (*Tree, error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	p, err := pool.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(p)
+	return p.Parse(ctx, src)
+}
+
+// matchAny reports whether rel, a slash-separated relative path, matches
+// one of patterns as described for ParseDirOptions.
+func matchAny(patterns []string, rel string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = base
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestParseFile(t *testing.T) {
+	for _, tt := range []struct {
+		filename, src string
+	}{
+		{"plugins/init.gza", "var x = 1;\n"},
+		{"bin/deploy", "#!/usr/bin/env ghostlang\nvar x = 1;\n"},
+		{"bin/deploy", "#!/usr/bin/env -S GHOST_DEBUG=1 ghostlang --strict\nvar x = 1;\n"},
+		{"bin/deploy", "#!/opt/ghost/bin/ghostlang\nvar x = 1;\n"},
+	} {
+		tree, lang, err := grove.ParseFile(context.Background(), tt.filename, []byte(tt.src))
+		if err != nil {
+			t.Errorf("ParseFile(%q, %q): %v", tt.filename, tt.src, err)
+			continue
+		}
+		if lang != ghostlang(t) || tree.Language() != lang {
+			t.Errorf("ParseFile(%q, %q) picked %s", tt.filename, tt.src, lang.Name())
+		}
+		tree.Close()
+	}
+}
+
+func TestParseFileUnknownLanguage(t *testing.T) {
+	for _, tt := range []struct {
+		filename, src string
+	}{
+		{"notes.txt", "var x = 1;\n"},
+		{"bin/deploy", "#!/bin/sh\necho hi\n"},
+		{"bin/deploy", "var x = 1;\n"},
+		{"bin/deploy", "#!/usr/bin/env\n"},
+	} {
+		tree, lang, err := grove.ParseFile(context.Background(), tt.filename, []byte(tt.src))
+		if tree != nil || lang != nil || !errors.Is(err, grove.ErrUnknownLanguage) {
+			t.Errorf("ParseFile(%q, %q) = %v, %v, %v; want ErrUnknownLanguage", tt.filename, tt.src, tree, lang, err)
+		}
+	}
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, src := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestParseDir(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"main.gza":              "var x = 1;\n",
+		"lib/util.ghost":        "function f() { return 1; }\n",
+		"lib/broken.gza":        "var = ;\n",
+		"lib/util_test.gza":     "var t = 1;\n",
+		"vendor/dep/dep.gza":    "var d = 1;\n",
+		"README.md":             "# not ghostlang\n",
+		"scripts/run":           "#!/usr/bin/env ghostlang\nvar r = 1;\n",
+		"scripts/gen/gen.ghost": "var g = 1;\n",
+	})
+	if err := os.Symlink(filepath.Join(root, "gone.gza"), filepath.Join(root, "dangling.gza")); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := grove.ParseDir(context.Background(), root, grove.ParseDirOptions{
+		Workers: 3,
+		Ignore:  []string{"vendor", "*_test.gza", "scripts/gen"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for r := range results {
+		rel, _ := filepath.Rel(root, r.Path)
+		rel = filepath.ToSlash(rel)
+		switch {
+		case r.Err != nil:
+			got[rel] = "error"
+		case r.Tree.HasError():
+			got[rel] = "syntax error"
+		default:
+			got[rel] = "ok"
+		}
+		if r.Tree != nil {
+			if r.Language != ghostlang(t) {
+				t.Errorf("%s parsed as %v", rel, r.Language)
+			}
+			r.Tree.Close()
+		}
+	}
+	want := map[string]string{
+		"main.gza":       "ok",
+		"lib/util.ghost": "ok",
+		"lib/broken.gza": "syntax error",
+		"dangling.gza":   "error",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseDir results = %v, want %v", got, want)
+	}
+}
+
+func TestParseDirInclude(t *testing.T) {
+	root := writeFiles(t, map[string]string{
+		"a.gza":     "var a = 1;\n",
+		"b/b.gza":   "var b = 1;\n",
+		"b/c.ghost": "var c = 1;\n",
+	})
+	results, err := grove.ParseDir(context.Background(), root, grove.ParseDirOptions{Include: []string{"*.gza"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for r := range results {
+		got = append(got, filepath.Base(r.Path))
+		r.Tree.Close()
+	}
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, []string{"a.gza", "b.gza"}) {
+		t.Errorf("ParseDir with Include = %v", got)
+	}
+}
+
+func TestParseDirCancel(t *testing.T) {
+	files := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		files[fmt.Sprintf("d%d/f%d.gza", i%10, i)] = string(largeSource(20))
+	}
+	root := writeFiles(t, files)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := grove.ParseDir(ctx, root, grove.ParseDirOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := <-results
+	if r.Tree != nil {
+		r.Tree.Close()
+	}
+	cancel()
+
+	n := 0
+	done := time.After(5 * time.Second)
+	for {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				if n >= len(files)-1 {
+					t.Errorf("cancellation did not stop the walk: got %d more results", n)
+				}
+				return
+			}
+			n++
+			if r.Tree != nil {
+				r.Tree.Close()
+			}
+		case <-done:
+			t.Fatal("results channel not closed after cancellation")
+		}
+	}
+}
+
+func TestParseDirNotADirectory(t *testing.T) {
+	root := writeFiles(t, map[string]string{"a.gza": "var a = 1;\n"})
+	if _, err := grove.ParseDir(context.Background(), filepath.Join(root, "a.gza"), grove.ParseDirOptions{}); err == nil {
+		t.Errorf("ParseDir accepted a file as root")
+	}
+	if _, err := grove.ParseDir(context.Background(), filepath.Join(root, "missing"), grove.ParseDirOptions{}); err == nil {
+		t.Errorf("ParseDir accepted a missing root")
+	}
+}
@@ -0,0 +1,102 @@
+package grove
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+)
+
+// fingerprintMagic opens data produced by Tree.Fingerprint, followed by the
+// format version.
+const (
+	fingerprintMagic   = "GRVT"
+	fingerprintVersion = 1
+)
+
+// Node flags in the fingerprint format.
+const (
+	nodeFlagNamed = 1 << iota
+	nodeFlagMissing
+	nodeFlagExtra
+)
+
+// Fingerprint encodes the tree's structure: each node's kind and field ids,
+// flags and byte range, in a compact varint format, headed by a format
+// version, a fingerprint of the grammar and a hash of the source. Two trees
+// have the same fingerprint exactly when they have the same structure over
+// the same source and grammar, so it serves to key a cache of results
+// derived from a parse or to detect that a re-parse changed it. tree-sitter
+// cannot rebuild a tree from its nodes, so there is no way back from a
+// fingerprint to a Tree.
+func (t *Tree) Fingerprint() ([]byte, error) {
+	if t.closed {
+		return nil, ErrClosed
+	}
+	var b bytes.Buffer
+	b.WriteString(fingerprintMagic)
+	b.WriteByte(fingerprintVersion)
+	putUvarint(&b, uint64(t.lang.fingerprint()))
+	putUvarint(&b, uint64(len(t.src)))
+	putUvarint(&b, srcHash(t.src))
+
+	var c tsCursor
+	c.init(t.RootNode().ts)
+	defer c.delete()
+	var prevStart uint32
+	for {
+		n := c.node(t.ts)
+		var flags byte
+		if n.IsNamed() {
+			flags |= nodeFlagNamed
+		}
+		if n.IsMissing() {
+			flags |= nodeFlagMissing
+		}
+		if n.IsExtra() {
+			flags |= nodeFlagExtra
+		}
+		start, end := n.StartByte(), n.EndByte()
+		putUvarint(&b, uint64(n.Symbol()))
+		putUvarint(&b, uint64(c.fieldID()))
+		b.WriteByte(flags)
+		putUvarint(&b, uint64(start-prevStart))
+		putUvarint(&b, uint64(end-start))
+		putUvarint(&b, uint64(n.ChildCount()))
+		prevStart = start
+
+		if c.gotoFirstChild() {
+			continue
+		}
+		for !c.gotoNextSibling() {
+			if !c.gotoParent() {
+				return b.Bytes(), nil
+			}
+		}
+	}
+}
+
+// fingerprint hashes the grammar's ABI version and its node kind and field
+// names, which change whenever the grammar's ids could.
+func (l *Language) fingerprint() uint32 {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%d\x00", l.Version())
+	for i := 0; i < l.NodeKindCount(); i++ {
+		fmt.Fprintf(h, "%s\x00%t\x00", l.NodeKindForID(uint16(i)), l.NodeKindIsNamed(uint16(i)))
+	}
+	for i := 1; i <= l.FieldCount(); i++ {
+		fmt.Fprintf(h, "%s\x00", l.FieldName(uint16(i)))
+	}
+	return h.Sum32()
+}
+
+func srcHash(src []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(src)
+	return h.Sum64()
+}
+
+func putUvarint(b *bytes.Buffer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
@@ -0,0 +1,45 @@
+package grove_test
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	src := "var x = 1;\n// note\nfunction f(a) { return a +; }\n"
+	tree := parse(t, src)
+	defer tree.Close()
+	data, err := tree.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("GRVT\x01")) {
+		t.Errorf("data starts with %q, want the magic and format version", data[:5])
+	}
+
+	again := parse(t, src)
+	defer again.Close()
+	if other, _ := again.Fingerprint(); !bytes.Equal(other, data) {
+		t.Errorf("two parses of the same source fingerprint differently")
+	}
+	copied := tree.Copy()
+	defer copied.Close()
+	if other, _ := copied.Fingerprint(); !bytes.Equal(other, data) {
+		t.Errorf("a copy of the tree fingerprints differently")
+	}
+
+	for _, changed := range []string{
+		"var y = 1;\n// note\nfunction f(a) { return a +; }\n", // same structure, other source
+		"var x = 1;\n// note\nfunction f(a) { return a; }\n",   // other structure
+	} {
+		tree := parse(t, changed)
+		other, err := tree.Fingerprint()
+		tree.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(other, data) {
+			t.Errorf("%q fingerprints like %q", changed, src)
+		}
+	}
+}
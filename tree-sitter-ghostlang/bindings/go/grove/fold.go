@@ -0,0 +1,157 @@
+package grove
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// FoldRange is a foldable region of source. Lines are zero-based. The byte
+// range runs from the end of the first line to the end of the last line, so
+// a folded region keeps its first line, such as an opening brace, visible.
+type FoldRange struct {
+	StartLine uint32
+	EndLine   uint32
+	StartByte uint32
+	EndByte   uint32
+	// Kind is "comment" for folds from @fold.comment captures and empty
+	// otherwise.
+	Kind string
+}
+
+// FoldProvider computes fold ranges with a folds query. Nodes captured as
+// @fold fold when they span more than one line. Nodes captured as
+// @fold.comment fold the same way, and runs of single-line comments on
+// consecutive lines collapse into one fold.
+type FoldProvider struct {
+	query         *Query
+	mergeSiblings bool
+}
+
+// FoldOption configures a FoldProvider.
+type FoldOption func(*FoldProvider)
+
+// WithMergedSiblings makes consecutive folds of the same kind, separated
+// only by whitespace, collapse into one fold.
+func WithMergedSiblings() FoldOption {
+	return func(f *FoldProvider) { f.mergeSiblings = true }
+}
+
+// NewFoldProvider returns a fold provider for query.
+func NewFoldProvider(query *Query, opts ...FoldOption) *FoldProvider {
+	f := &FoldProvider{query: query}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// foldNode is a captured node while folds are being merged.
+type foldNode struct {
+	start, end       uint32
+	startRow, endRow int
+	kind             string
+	single           bool // the last merged node is a one-line comment
+}
+
+// Folds returns the fold ranges of tree, sorted by start. It returns nil if
+// the query is for a different language than the tree.
+func (f *FoldProvider) Folds(tree *Tree) []FoldRange {
+	if tree.Language() != f.query.Language() {
+		return nil
+	}
+	nodes := f.collect(tree)
+	lines := tree.lines()
+
+	// last holds, per kind, the fold reaching furthest so far: the only one
+	// that the next fold of that kind can directly follow.
+	last := make(map[string]int)
+	var merged []foldNode
+	for _, n := range nodes {
+		i, ok := last[n.kind]
+		if ok && f.joins(tree.src, merged[i], n) {
+			merged[i].end, merged[i].endRow, merged[i].single = n.end, n.endRow, n.single
+			continue
+		}
+		if !ok || n.end > merged[i].end {
+			last[n.kind] = len(merged)
+		}
+		merged = append(merged, n)
+	}
+
+	var folds []FoldRange
+	for _, n := range merged {
+		if n.endRow <= n.startRow {
+			continue
+		}
+		_, startEnd := lines.lineBounds(n.startRow)
+		_, endEnd := lines.lineBounds(n.endRow)
+		folds = append(folds, FoldRange{
+			StartLine: uint32(n.startRow),
+			EndLine:   uint32(n.endRow),
+			StartByte: startEnd,
+			EndByte:   endEnd,
+			Kind:      n.kind,
+		})
+	}
+	return folds
+}
+
+// collect returns the captured nodes of tree, one per byte range, sorted by
+// start and then by descending end.
+func (f *FoldProvider) collect(tree *Tree) []foldNode {
+	lines := tree.lines()
+	type span struct{ start, end uint32 }
+	seen := make(map[span]bool)
+	var nodes []foldNode
+	matches := f.query.Matches(tree.RootNode())
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		for _, c := range m.Captures {
+			var kind string
+			switch {
+			case c.Name == "fold":
+			case strings.HasPrefix(c.Name, "fold."):
+				kind = strings.TrimPrefix(c.Name, "fold.")
+			default:
+				continue
+			}
+			s := span{c.Node.StartByte(), c.Node.EndByte()}
+			if seen[s] || s.start == s.end {
+				continue
+			}
+			seen[s] = true
+			startRow, endRow := lines.row(s.start), lines.row(s.end)
+			if endRow > startRow && lines.starts[endRow] == s.end {
+				// A node ending with a newline ends on the line before.
+				endRow--
+			}
+			nodes = append(nodes, foldNode{
+				start:    s.start,
+				end:      s.end,
+				startRow: startRow,
+				endRow:   endRow,
+				kind:     kind,
+				single:   kind == "comment" && startRow == endRow,
+			})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].start != nodes[j].start {
+			return nodes[i].start < nodes[j].start
+		}
+		return nodes[i].end > nodes[j].end
+	})
+	return nodes
+}
+
+// joins reports whether next continues the fold prev, given that they have
+// the same kind.
+func (f *FoldProvider) joins(src []byte, prev, next foldNode) bool {
+	if next.start < prev.end || len(bytes.TrimSpace(src[prev.end:next.start])) > 0 {
+		return false
+	}
+	if prev.kind == "comment" && prev.single && next.single && next.startRow == prev.endRow+1 {
+		return true
+	}
+	return f.mergeSiblings
+}
@@ -0,0 +1,78 @@
+package grove_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func foldsQuery(t *testing.T) *grove.Query {
+	t.Helper()
+	source, err := os.ReadFile("../../../queries/folds.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(t), string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func foldLines(folds []grove.FoldRange) []string {
+	var out []string
+	for _, f := range folds {
+		out = append(out, fmt.Sprintf("%s%d-%d", f.Kind, f.StartLine, f.EndLine))
+	}
+	return out
+}
+
+func TestFolds(t *testing.T) {
+	src := `// one
+// two
+// three
+function f(a) {
+  if (a) {
+    return 1;
+  }
+  if (!a) {
+    return 2;
+  }
+  /* block
+     comment */
+}
+var x = { a: 1 };
+{
+  a();
+}
+{
+  b();
+}
+`
+	tree := parse(t, src)
+	defer tree.Close()
+
+	for _, tt := range []struct {
+		opts []grove.FoldOption
+		want string
+	}{
+		{nil, "[comment0-2 3-12 4-6 7-9 comment10-11 14-16 17-19]"},
+		{[]grove.FoldOption{grove.WithMergedSiblings()}, "[comment0-2 3-12 4-6 7-9 comment10-11 14-19]"},
+	} {
+		folds := grove.NewFoldProvider(foldsQuery(t), tt.opts...).Folds(tree)
+		if got := fmt.Sprint(foldLines(folds)); got != tt.want {
+			t.Errorf("Folds(%d options) = %s, want %s", len(tt.opts), got, tt.want)
+		}
+	}
+
+	folds := grove.NewFoldProvider(foldsQuery(t)).Folds(tree)
+	body := folds[1]
+	if got := src[body.StartByte-1 : body.StartByte]; got != "{" {
+		t.Errorf("function fold starts after %q, want after the opening brace", got)
+	}
+	if got := src[body.EndByte-1 : body.EndByte+1]; got != "}\n" {
+		t.Errorf("function fold ends at %q, want the end of the closing line", got)
+	}
+}
@@ -0,0 +1,282 @@
+// Package grovetest provides helpers for testing grammars and tools built on
+// grove, in the style of tree-sitter's corpus tests:
+//
+//	func TestVariable(t *testing.T) {
+//		tree := grovetest.MustParse(t, lang, "var x = 5;")
+//		grovetest.AssertSExp(t, tree, `
+//			(source_file
+//			  (statement
+//			    (variable_declaration
+//			      name: (identifier)
+//			      value: (expression ...))))`)
+//	}
+package grovetest
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// MustParse parses src with a new parser for lang, failing the test at once
+// if the parser cannot be created or the parse fails. Syntax errors do not
+// fail it: they show up as ERROR and MISSING nodes in the tree. The tree is
+// closed when the test finishes.
+func MustParse(t testing.TB, lang *grove.Language, src string) *grove.Tree {
+	t.Helper()
+	p, err := grove.NewParser(lang)
+	if err != nil {
+		t.Fatalf("grovetest: NewParser(%s): %v", lang.Name(), err)
+	}
+	defer p.Close()
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatalf("grovetest: parsing %q: %v", src, err)
+	}
+	t.Cleanup(tree.Close)
+	return tree
+}
+
+// AssertSExp reports an error if the tree's structure differs from want, an
+// S-expression as printed by Tree.String or `tree-sitter parse`. Whitespace
+// and node positions in want are insignificant, so it may be indented freely
+// and pasted from either. Anonymous tokens such as "var" or ";" are compared
+// only if want contains some, in which case it must list every one of them.
+// A node in want may end its children with ..., as in (expression ...), to
+// match any further children and their subtrees.
+//
+// On a mismatch the error names the path to the first node that differs and
+// shows the expected and actual subtrees there, followed by the whole actual
+// tree for updating want. A want that is not a valid S-expression fails the
+// test at once.
+func AssertSExp(t testing.TB, tree *grove.Tree, want string) {
+	t.Helper()
+	expected, err := parseSExp(want)
+	if err != nil {
+		t.Fatalf("grovetest: expected S-expression: %v", err)
+	}
+	actual, err := parseSExp(tree.RootNode().SExp(grove.SExpOptions{IncludeAnonymous: expected.hasAnonymous()}))
+	if err != nil {
+		t.Fatalf("grovetest: tree S-expression: %v", err)
+	}
+	if path, w, g, ok := diff(expected, actual, nil); !ok {
+		t.Errorf("syntax tree differs at %s\nwant:\n%s\ngot:\n%s\nwhole tree:\n%s",
+			strings.Join(path, " > "), w.indent("  "), g.indent("  "), actual.indent("  "))
+	}
+}
+
+// sexpNode is a node of a parsed S-expression.
+type sexpNode struct {
+	field    string
+	kind     string
+	named    bool // (kind) rather than "kind"
+	missing  bool
+	children []*sexpNode
+	rest     bool // children end with ..., matching any more
+}
+
+func (n *sexpNode) hasAnonymous() bool {
+	if !n.named && !n.missing {
+		return true
+	}
+	for _, c := range n.children {
+		if c.hasAnonymous() {
+			return true
+		}
+	}
+	return false
+}
+
+// label is how n appears in a diff path, such as `value: expression` or
+// `";"`.
+func (n *sexpNode) label() string {
+	var b strings.Builder
+	if n.field != "" {
+		b.WriteString(n.field + ": ")
+	}
+	if n.missing {
+		b.WriteString("MISSING ")
+	}
+	if n.named {
+		b.WriteString(n.kind)
+	} else {
+		b.WriteString(strconv.Quote(n.kind))
+	}
+	return b.String()
+}
+
+// indent returns n as an S-expression with one child per line, each line
+// starting with prefix.
+func (n *sexpNode) indent(prefix string) string {
+	var b strings.Builder
+	n.write(&b, prefix)
+	return b.String()
+}
+
+func (n *sexpNode) write(b *strings.Builder, prefix string) {
+	b.WriteString(prefix)
+	if n.field != "" {
+		b.WriteString(n.field + ": ")
+	}
+	if !n.named && !n.missing {
+		b.WriteString(strconv.Quote(n.kind))
+		return
+	}
+	b.WriteByte('(')
+	if n.missing {
+		b.WriteString("MISSING ")
+	}
+	if n.named {
+		b.WriteString(n.kind)
+	} else {
+		b.WriteString(strconv.Quote(n.kind))
+	}
+	for _, c := range n.children {
+		b.WriteByte('\n')
+		c.write(b, prefix+"  ")
+	}
+	if n.rest {
+		b.WriteString("\n" + prefix + "  ...")
+	}
+	b.WriteByte(')')
+}
+
+// diff compares want and got, whose parents lie along path. If they differ
+// it returns the path to the first differing node and that node on each
+// side; when one side has an extra child, that is the node itself.
+func diff(want, got *sexpNode, path []string) (at []string, w, g *sexpNode, ok bool) {
+	path = append(path, got.label())
+	if want.field != got.field || want.kind != got.kind || want.named != got.named || want.missing != got.missing {
+		return path, want, got, false
+	}
+	for i := 0; i < len(want.children) && i < len(got.children); i++ {
+		if at, w, g, ok := diff(want.children[i], got.children[i], path); !ok {
+			// Fields name their child; otherwise count siblings.
+			if got.children[i].field == "" && (len(want.children) > 1 || len(got.children) > 1) {
+				at[len(path)] += fmt.Sprintf("[%d]", i)
+			}
+			return at, w, g, false
+		}
+	}
+	if len(want.children) != len(got.children) && !(want.rest && len(got.children) > len(want.children)) {
+		return path, want, got, false
+	}
+	return nil, nil, nil, true
+}
+
+// positions matches the node positions printed by `tree-sitter parse`.
+var positions = regexp.MustCompile(`\[\d+, \d+\] - \[\d+, \d+\]`)
+
+// parseSExp parses an S-expression of the form printed by Node.SExp,
+// ignoring node positions, with ... allowed after a node's children.
+func parseSExp(s string) (*sexpNode, error) {
+	p := &sexpParser{src: positions.ReplaceAllLiteralString(s, "")}
+	n, err := p.node("")
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok != "" {
+		return nil, p.errorf("unexpected %q after the root node", tok)
+	}
+	return n, nil
+}
+
+type sexpParser struct {
+	src string
+	pos int
+}
+
+func (p *sexpParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// next returns the next token: "(", ")", a quoted string, or a word, which
+// includes the colon of a field name. It returns "" at the end.
+func (p *sexpParser) next() string {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	if p.pos == len(p.src) {
+		return ""
+	}
+	start := p.pos
+	switch p.src[p.pos] {
+	case '(', ')':
+		p.pos++
+	case '"':
+		for p.pos++; p.pos < len(p.src) && p.src[p.pos] != '"'; p.pos++ {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+		}
+		p.pos = min(p.pos+1, len(p.src))
+	default:
+		for p.pos < len(p.src) && strings.IndexByte(" \t\r\n()\"", p.src[p.pos]) < 0 {
+			p.pos++
+		}
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *sexpParser) node(field string) (*sexpNode, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, p.errorf("unexpected end of S-expression")
+	case tok[0] == '"':
+		kind, err := strconv.Unquote(tok)
+		if err != nil {
+			return nil, p.errorf("malformed token %s", tok)
+		}
+		return &sexpNode{field: field, kind: kind}, nil
+	case tok != "(":
+		return nil, p.errorf("unexpected %q", tok)
+	}
+
+	n := &sexpNode{field: field, named: true}
+	n.kind = p.next()
+	if n.kind == "MISSING" {
+		n.missing = true
+		n.kind = p.next()
+		if strings.HasPrefix(n.kind, `"`) {
+			kind, err := strconv.Unquote(n.kind)
+			if err != nil {
+				return nil, p.errorf("malformed token %s", n.kind)
+			}
+			n.kind, n.named = kind, false
+		}
+	}
+	if n.named && (n.kind == "" || strings.ContainsAny(n.kind, `()":`)) {
+		return nil, p.errorf("missing node kind")
+	}
+	for {
+		save := p.pos
+		tok := p.next()
+		if tok == ")" {
+			return n, nil
+		}
+		if tok == "..." {
+			if p.next() != ")" {
+				return nil, p.errorf("... must end the children of %s", n.kind)
+			}
+			n.rest = true
+			return n, nil
+		}
+		var childField string
+		if name, ok := strings.CutSuffix(tok, ":"); ok && name != "" {
+			childField = name
+		} else {
+			p.pos = save
+		}
+		child, err := p.node(childField)
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, child)
+	}
+}
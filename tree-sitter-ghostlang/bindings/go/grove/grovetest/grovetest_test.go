@@ -0,0 +1,148 @@
+package grovetest_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/ghostlang"
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove/grovetest"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	runtime.Goexit()
+}
+
+// record runs fn with a recorder on a goroutine of its own, so that Fatalf
+// can stop it.
+func record(fn func(t testing.TB)) *recorder {
+	r := &recorder{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(r)
+	}()
+	<-done
+	return r
+}
+
+const chain = `(expression (conditional_expression (logical_or_expression
+	(logical_and_expression (equality_expression (relational_expression
+	(additive_expression (multiplicative_expression (unary_expression
+	(postfix_expression (primary_expression %s)))))))))))`
+
+func TestAssertSExp(t *testing.T) {
+	tree := grovetest.MustParse(t, ghostlang.Language(), "var x = 5;\nvar y = \"s\";\n")
+	number := fmt.Sprintf(chain, "(number_literal)")
+	str := fmt.Sprintf(chain, "(string_literal)")
+	grovetest.AssertSExp(t, tree, `
+		(source_file
+		  (statement
+		    (variable_declaration
+		      name: (identifier)
+		      value: `+number+`))
+		  (statement
+		    (variable_declaration
+		      name: (identifier)
+		      value: `+str+`)))`)
+
+	// Positions, as printed by tree-sitter parse, are ignored.
+	grovetest.AssertSExp(t, grovetest.MustParse(t, ghostlang.Language(), ";"),
+		"(source_file [0, 0] - [0, 1] (statement [0, 0] - [0, 1] (empty_statement [0, 0] - [0, 1])))")
+
+	// Anonymous tokens are compared when want lists them.
+	grovetest.AssertSExp(t, grovetest.MustParse(t, ghostlang.Language(), ";"),
+		`(source_file (statement (empty_statement ";")))`)
+
+	// ... stands for any further children, as in the package example.
+	grovetest.AssertSExp(t, grovetest.MustParse(t, ghostlang.Language(), "var x = 5;"), `
+		(source_file
+		  (statement
+		    (variable_declaration
+		      name: (identifier)
+		      value: (expression ...))))`)
+	grovetest.AssertSExp(t, tree, "(source_file (statement ...) ...)")
+}
+
+func TestAssertSExpDiff(t *testing.T) {
+	tree := grovetest.MustParse(t, ghostlang.Language(), "var x = 5;\nvar y = \"s\";\n")
+	number := fmt.Sprintf(chain, "(number_literal)")
+	r := record(func(t testing.TB) {
+		grovetest.AssertSExp(t, tree, `
+			(source_file
+			  (statement (variable_declaration name: (identifier) value: `+number+`))
+			  (statement (variable_declaration name: (identifier) value: `+number+`)))`)
+	})
+	if len(r.errors) != 1 || r.fatal {
+		t.Fatalf("AssertSExp reported %q, fatal %v; want one error", r.errors, r.fatal)
+	}
+	msg := r.errors[0]
+	for _, want := range []string{
+		"differs at source_file > statement[1] > variable_declaration > value: expression > conditional_expression",
+		"> primary_expression > string_literal\n",
+		"want:\n  (number_literal)\ngot:\n  (string_literal)\n",
+		"whole tree:\n  (source_file\n    (statement\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("diff lacks %q:\n%s", want, msg)
+		}
+	}
+
+	// A node with more children than expected is shown whole.
+	r = record(func(t testing.TB) {
+		grovetest.AssertSExp(t, tree, "(source_file (statement (variable_declaration name: (identifier) value: "+number+")))")
+	})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "differs at source_file\n") {
+		t.Errorf("AssertSExp with a missing statement reported %q", r.errors)
+	}
+
+	// ... matches more children, not fewer.
+	r = record(func(t testing.TB) {
+		grovetest.AssertSExp(t, tree, "(source_file (statement ...) (statement ...) (statement ...) ...)")
+	})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "differs at source_file\nwant:\n  (source_file\n") ||
+		!strings.Contains(r.errors[0], "\n    ...)\n") {
+		t.Errorf("AssertSExp with too many statements before ... reported %q", r.errors)
+	}
+}
+
+func TestAssertSExpMalformed(t *testing.T) {
+	tree := grovetest.MustParse(t, ghostlang.Language(), ";")
+	for _, want := range []string{
+		"",
+		"(source_file",
+		"(source_file))",
+		"source_file",
+		"(source_file (statement \"unterminated))",
+		"(source_file ... (statement))",
+		"...",
+	} {
+		r := record(func(t testing.TB) { grovetest.AssertSExp(t, tree, want) })
+		if !r.fatal {
+			t.Errorf("AssertSExp(%q) did not fail the test at once: %q", want, r.errors)
+		}
+	}
+}
+
+func TestMustParseKeepsSyntaxErrors(t *testing.T) {
+	tree := grovetest.MustParse(t, ghostlang.Language(), "var x = ;")
+	if !tree.HasError() {
+		t.Errorf("tree of invalid source has no errors: %s", tree)
+	}
+}
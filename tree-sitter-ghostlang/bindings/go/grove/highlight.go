@@ -0,0 +1,172 @@
+package grove
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// HighlightSpan is a run of source text highlighted with one capture.
+type HighlightSpan struct {
+	StartByte uint32
+	EndByte   uint32
+	Capture   string
+	// Class is the theme index for Capture, or -1 if the highlighter has no
+	// class for it.
+	Class int
+}
+
+// Highlighter produces highlight spans from a highlights query.
+//
+// When several captures apply to the same node, the one from the last
+// pattern in the query wins, so generic patterns should come before specific
+// ones. When captured nodes nest, the innermost one wins over its range.
+// Captures whose names start with an underscore are ignored.
+type Highlighter struct {
+	query   *Query
+	classes map[string]int
+	parsers *ParserPool
+}
+
+// NewHighlighter returns a highlighter for query. classes optionally maps
+// capture names to theme indexes; a capture without an entry of its own uses
+// the entry of its longest dotted prefix, so "function.call" falls back to
+// "function".
+func NewHighlighter(query *Query, classes map[string]int) *Highlighter {
+	return &Highlighter{
+		query:   query,
+		classes: classes,
+		parsers: NewParserPool(query.Language(), 0),
+	}
+}
+
+// Highlight parses src and returns its highlight spans. Spans are sorted,
+// do not overlap, and leave uncaptured text uncovered.
+func (h *Highlighter) Highlight(src []byte) ([]HighlightSpan, error) {
+	ctx := context.Background()
+	p, err := h.parsers.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer h.parsers.Put(p)
+	tree, err := p.Parse(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Close()
+	return h.HighlightTree(tree), nil
+}
+
+// HighlightTree returns the highlight spans of an already parsed tree.
+func (h *Highlighter) HighlightTree(tree *Tree) []HighlightSpan {
+	return h.resolve(h.collect(tree.RootNode()))
+}
+
+// highlightCapture is a capture that competes for a byte range.
+type highlightCapture struct {
+	start, end uint32
+	pattern    int
+	index      uint32
+	name       string
+}
+
+// collect gathers the captures of node, keeping one per byte range.
+func (h *Highlighter) collect(node *Node) []highlightCapture {
+	type span struct{ start, end uint32 }
+	best := make(map[span]highlightCapture)
+	matches := h.query.Matches(node)
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		for _, c := range m.Captures {
+			if strings.HasPrefix(c.Name, "_") {
+				continue
+			}
+			hc := highlightCapture{
+				start:   c.Node.StartByte(),
+				end:     c.Node.EndByte(),
+				pattern: m.PatternIndex,
+				index:   c.Index,
+				name:    c.Name,
+			}
+			if hc.start == hc.end {
+				continue
+			}
+			key := span{hc.start, hc.end}
+			if prev, ok := best[key]; ok && !laterCapture(hc, prev) {
+				continue
+			}
+			best[key] = hc
+		}
+	}
+
+	captures := make([]highlightCapture, 0, len(best))
+	for _, hc := range best {
+		captures = append(captures, hc)
+	}
+	sort.Slice(captures, func(i, j int) bool {
+		if captures[i].start != captures[j].start {
+			return captures[i].start < captures[j].start
+		}
+		return captures[i].end > captures[j].end
+	})
+	return captures
+}
+
+func laterCapture(a, b highlightCapture) bool {
+	if a.pattern != b.pattern {
+		return a.pattern > b.pattern
+	}
+	return a.index > b.index
+}
+
+// resolve flattens nested captures, sorted by start and then by descending
+// end, into non-overlapping spans in which the innermost capture wins.
+func (h *Highlighter) resolve(captures []highlightCapture) []HighlightSpan {
+	var spans []HighlightSpan
+	emit := func(start, end uint32, hc highlightCapture) {
+		if start >= end {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].EndByte == start && spans[n-1].Capture == hc.name {
+			spans[n-1].EndByte = end
+			return
+		}
+		spans = append(spans, HighlightSpan{StartByte: start, EndByte: end, Capture: hc.name, Class: h.classFor(hc.name)})
+	}
+
+	var stack []highlightCapture
+	var pos uint32
+	for _, hc := range captures {
+		for len(stack) > 0 && stack[len(stack)-1].end <= hc.start {
+			top := stack[len(stack)-1]
+			emit(pos, top.end, top)
+			pos = max(pos, top.end)
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 {
+			emit(pos, hc.start, stack[len(stack)-1])
+		}
+		pos = max(pos, hc.start)
+		stack = append(stack, hc)
+	}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		emit(pos, top.end, top)
+		pos = max(pos, top.end)
+		stack = stack[:len(stack)-1]
+	}
+	return spans
+}
+
+func (h *Highlighter) classFor(capture string) int {
+	for name := capture; name != ""; {
+		if class, ok := h.classes[name]; ok {
+			return class
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return -1
+}
@@ -0,0 +1,54 @@
+package grove_test
+
+import (
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+const testHighlights = `
+(identifier) @variable
+"var" @keyword
+(string_literal) @string
+(escape_sequence) @string.escape
+(call_expression
+  function: (postfix_expression
+    (primary_expression
+      (identifier) @function.call)))
+`
+
+func TestHighlight(t *testing.T) {
+	q, err := grove.NewQuery(ghostlang(t), testHighlights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := grove.NewHighlighter(q, map[string]int{"keyword": 1, "function": 2, "string": 3})
+
+	src := []byte(`var x = log("a\n");`)
+	spans, err := h.Highlight(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type span struct {
+		text, capture string
+		class         int
+	}
+	want := []span{
+		{"var", "keyword", 1},
+		{"x", "variable", -1},
+		{"log", "function.call", 2},
+		{`"a`, "string", 3},
+		{`\n`, "string.escape", 3},
+		{`"`, "string", 3},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("got %d spans %+v, want %d", len(spans), spans, len(want))
+	}
+	for i, s := range spans {
+		got := span{string(src[s.StartByte:s.EndByte]), s.Capture, s.Class}
+		if got != want[i] {
+			t.Errorf("span %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
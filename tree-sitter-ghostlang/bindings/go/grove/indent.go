@@ -0,0 +1,89 @@
+package grove
+
+// Indenter computes indentation levels with an indents query. It uses these
+// captures:
+//
+//   - @indent.begin: lines after the first line of the node, through its
+//     last line, are indented one level. Several nodes starting on the
+//     same line add only one level between them.
+//   - @indent.dedent: the reverse of @indent.begin; the node's later lines
+//     lose one level.
+//   - @indent.end and @indent.branch: a line starting with the node is
+//     indented one level less, as for a closing "}" or for "else".
+//   - @indent.ignore: lines starting inside the node after its first line,
+//     such as those of multi-line strings and comments, are left alone.
+type Indenter struct {
+	query *Query
+}
+
+// NewIndenter returns an indenter for query.
+func NewIndenter(query *Query) *Indenter {
+	return &Indenter{query: query}
+}
+
+// IndentLevelAt returns the indentation level, in indent units, that the
+// zero-based line should have. It returns -1 for lines that should keep
+// their existing indentation, and for a query of a different language than
+// the tree. The query only visits the nodes around the line, so the cost
+// does not grow with the size of the file.
+func (in *Indenter) IndentLevelAt(tree *Tree, line int) int {
+	if tree.Language() != in.query.Language() || line < 0 {
+		return -1
+	}
+	lines := tree.lines()
+	last := len(lines.starts) - 1
+	// first is the offset of the first non-blank byte on the line, or -1
+	// for a blank line.
+	first := -1
+	var lineStart, lineEnd uint32
+	if line <= last {
+		lineStart, lineEnd = lines.lineBounds(line)
+		for i := lineStart; i < lineEnd; i++ {
+			if c := tree.src[i]; c != ' ' && c != '\t' {
+				first = int(i)
+				break
+			}
+		}
+	} else {
+		lineStart = uint32(len(tree.src))
+		lineEnd = lineStart
+	}
+
+	begins := make(map[int]bool)
+	dedents := make(map[int]bool)
+	closes := false
+	// Every capture that affects the line overlaps it, or ends where it
+	// starts, so the query need only run over the line.
+	matches := in.query.MatchesInRange(tree.RootNode(), max(lineStart, 1)-1, lineEnd+1)
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		for _, c := range m.Captures {
+			start, end := c.Node.StartByte(), c.Node.EndByte()
+			startRow, endRow := lines.row(start), lines.row(end)
+			inside := startRow < line && line <= endRow
+			switch c.Name {
+			case "indent.begin":
+				if inside {
+					begins[startRow] = true
+				}
+			case "indent.dedent":
+				if inside {
+					dedents[startRow] = true
+				}
+			case "indent.end", "indent.branch":
+				if first >= 0 && start == uint32(first) {
+					closes = true
+				}
+			case "indent.ignore":
+				if start < lineStart && lineStart < end {
+					matches.Close()
+					return -1
+				}
+			}
+		}
+	}
+	level := len(begins) - len(dedents)
+	if closes {
+		level--
+	}
+	return max(level, 0)
+}
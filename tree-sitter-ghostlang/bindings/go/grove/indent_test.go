@@ -0,0 +1,79 @@
+package grove_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestIndentLevelAt(t *testing.T) {
+	source, err := os.ReadFile("../../../queries/indents.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(t), string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each line is prefixed with the level it should have, or "-" for one
+	// that must be left alone.
+	lines := []string{
+		"0|function f(a) {",
+		"1|if (a) {",
+		"2|call({",
+		"3|key: [",
+		"4|1,",
+		"3|],",
+		"2|});",
+		"1|} else {",
+		"2|var s = \"multi",
+		"-|   line\";",
+		"2|/* comment",
+		"-|   more */",
+		"2|",
+		"1|}",
+		"0|}",
+		"0|",
+	}
+	var src strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			src.WriteByte('\n')
+		}
+		src.WriteString(l[2:])
+	}
+	tree := parse(t, src.String())
+	defer tree.Close()
+
+	indenter := grove.NewIndenter(q)
+	for i, l := range lines {
+		want := -1
+		if l[0] != '-' {
+			want = int(l[0] - '0')
+		}
+		if got := indenter.IndentLevelAt(tree, i); got != want {
+			t.Errorf("line %d %q: IndentLevelAt = %d, want %d", i, l[2:], got, want)
+		}
+	}
+}
+
+func BenchmarkIndentLevelAt(b *testing.B) {
+	source, err := os.ReadFile("../../../queries/indents.scm")
+	if err != nil {
+		b.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(b), string(source))
+	if err != nil {
+		b.Fatal(err)
+	}
+	tree := parse(b, string(largeSource(2000)))
+	defer tree.Close()
+	in := grove.NewIndenter(q)
+	line := strings.Count(string(tree.Source()), "\n") / 2
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		in.IndentLevelAt(tree, line)
+	}
+}
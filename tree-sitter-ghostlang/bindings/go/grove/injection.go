@@ -0,0 +1,77 @@
+package grove
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Injection is a region of a document written in another language, found by
+// an injections query.
+type Injection struct {
+	// Language is the injected language's name, from an
+	// @injection.language capture or, failing that, a
+	// #set! injection.language directive.
+	Language string
+	// Resolved is the registered language for Language, or nil if the
+	// registry has none.
+	Resolved *Language
+	// Range is the span of the @injection.content node. It can be passed to
+	// Parser.SetIncludedRanges to parse the region on its own.
+	Range Range
+}
+
+// Injector finds embedded-language regions with an injections query, using
+// the @injection.content and @injection.language captures and the
+// injection.language property.
+type Injector struct {
+	query    *Query
+	registry *Registry
+}
+
+// NewInjector returns an injector for query that resolves language names in
+// registry, or in DefaultRegistry if registry is nil.
+func NewInjector(query *Query, registry *Registry) *Injector {
+	if registry == nil {
+		registry = DefaultRegistry
+	}
+	return &Injector{query: query, registry: registry}
+}
+
+// Injections returns the injections in tree, in document order. Matches
+// without an @injection.content capture or without any language name are
+// skipped; injections of unregistered languages are kept with a nil
+// Resolved.
+func (i *Injector) Injections(tree *Tree) ([]Injection, error) {
+	if tree.Language() != i.query.Language() {
+		return nil, fmt.Errorf("grove: injections query is for %s, tree is %s", i.query.Language().Name(), tree.Language().Name())
+	}
+	var out []Injection
+	matches := i.query.Matches(tree.RootNode())
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		content := m.Capture("injection.content")
+		if content == nil {
+			continue
+		}
+		var name string
+		if n := m.Capture("injection.language"); n != nil {
+			name = strings.TrimSpace(n.Utf8Text())
+		}
+		if name == "" {
+			name, _ = i.query.property(m.PatternIndex, "injection.language")
+		}
+		if name == "" {
+			continue
+		}
+		resolved, _ := i.registry.Get(name)
+		out = append(out, Injection{
+			Language: name,
+			Resolved: resolved,
+			Range:    content.Range(),
+		})
+	}
+	sort.SliceStable(out, func(a, b int) bool {
+		return out[a].Range.StartByte < out[b].Range.StartByte
+	})
+	return out, nil
+}
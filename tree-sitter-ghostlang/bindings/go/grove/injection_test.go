@@ -0,0 +1,63 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestInjections(t *testing.T) {
+	source := `(call_expression
+  function: (_) @injection.language
+  arguments: (argument_list (_) @injection.content))
+
+((object_literal) @injection.content
+ (#set! injection.language "json")
+ (#set! injection.include-children))`
+	q, err := grove.NewQuery(ghostlang(t), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := `ghostlang("var x = 1;");
+var cfg = { a: 1 };
+yaml("a: 1");
+`
+	tree := parse(t, src)
+	defer tree.Close()
+
+	got, err := grove.NewInjector(q, nil).Injections(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		lang, text string
+		resolved   bool
+	}{
+		{"ghostlang", `"var x = 1;"`, true},
+		{"json", "{ a: 1 }", false},
+		{"yaml", `"a: 1"`, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d injections, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		inj := got[i]
+		if inj.Language != w.lang {
+			t.Errorf("injection %d language = %q, want %q", i, inj.Language, w.lang)
+		}
+		if (inj.Resolved != nil) != w.resolved {
+			t.Errorf("injection %d resolved = %v, want resolved %v", i, inj.Resolved, w.resolved)
+		}
+		if text := src[inj.Range.StartByte:inj.Range.EndByte]; text != w.text {
+			t.Errorf("injection %d covers %q, want %q", i, text, w.text)
+		}
+		line := strings.Count(src[:inj.Range.StartByte], "\n")
+		if inj.Range.StartPoint.Row != uint32(line) || inj.Range.EndPoint.Row != uint32(line) {
+			t.Errorf("injection %d spans rows %d-%d, want %d", i, inj.Range.StartPoint.Row, inj.Range.EndPoint.Row, line)
+		}
+	}
+	if got[0].Resolved != ghostlang(t) {
+		t.Errorf("ghostlang injection resolved to %v", got[0].Resolved)
+	}
+}
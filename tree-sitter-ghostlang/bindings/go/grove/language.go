@@ -0,0 +1,181 @@
+package grove
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Language is a tree-sitter grammar known to Grove.
+type Language struct {
+	serial       uint64
+	name         string
+	ptr          unsafe.Pointer
+	ts           *sitter.Language
+	commentKinds map[string]bool
+	stringKinds  map[string]bool
+
+	fieldsOnce sync.Once
+	fieldNames []string // indexed by field id; 0 is unused
+
+	lib *dynamicLibrary // set by LoadDynamicLanguage
+}
+
+// LanguageOption configures a Language at construction.
+type LanguageOption func(*Language)
+
+// WithCommentKinds sets the node kinds that Tree.ScopeAt treats as comments,
+// replacing the default of "comment", "line_comment" and "block_comment".
+func WithCommentKinds(kinds ...string) LanguageOption {
+	return func(l *Language) { l.commentKinds = kindSet(kinds) }
+}
+
+// WithStringKinds sets the node kinds that Tree.ScopeAt treats as strings,
+// replacing the default of "string", "string_literal", "template_string"
+// and "raw_string_literal".
+func WithStringKinds(kinds ...string) LanguageOption {
+	return func(l *Language) { l.stringKinds = kindSet(kinds) }
+}
+
+func kindSet(kinds []string) map[string]bool {
+	set := make(map[string]bool, len(kinds))
+	for _, k := range kinds {
+		set[k] = true
+	}
+	return set
+}
+
+// The range of grammar ABI versions the linked tree-sitter runtime can load.
+const (
+	MinCompatibleLanguageVersion = tsMinCompatibleLanguageVersion
+	LanguageVersion              = tsLanguageVersionMax
+)
+
+// ErrIncompatibleLanguage is returned, wrapped, for a grammar whose ABI
+// version the tree-sitter runtime cannot load.
+var ErrIncompatibleLanguage = errors.New("grove: incompatible language version")
+
+// CheckCompatible returns an error wrapping ErrIncompatibleLanguage if lang
+// was generated for an ABI version outside MinCompatibleLanguageVersion to
+// LanguageVersion. Such a grammar would crash or mis-parse if used.
+func CheckCompatible(lang *Language) error {
+	v := lang.Version()
+	if v < MinCompatibleLanguageVersion || v > LanguageVersion {
+		return fmt.Errorf("%w: %s has ABI version %d, the runtime supports %d to %d",
+			ErrIncompatibleLanguage, lang.name, v, MinCompatibleLanguageVersion, LanguageVersion)
+	}
+	return nil
+}
+
+// languageSerial numbers the languages made by NewLanguage.
+var languageSerial atomic.Uint64
+
+// NewLanguage wraps the TSLanguage pointer returned by a grammar's
+// tree_sitter_<name>() entry point. It fails if CheckCompatible rejects the
+// grammar.
+func NewLanguage(name string, ptr unsafe.Pointer, opts ...LanguageOption) (*Language, error) {
+	l := &Language{
+		serial:       languageSerial.Add(1),
+		name:         name,
+		ptr:          ptr,
+		ts:           sitter.NewLanguage(ptr),
+		commentKinds: kindSet([]string{"comment", "line_comment", "block_comment"}),
+		stringKinds:  kindSet([]string{"string", "string_literal", "template_string", "raw_string_literal"}),
+	}
+	if err := CheckCompatible(l); err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l, nil
+}
+
+// Name returns the name the language was created with.
+func (l *Language) Name() string {
+	return l.name
+}
+
+// Pointer returns the underlying TSLanguage pointer.
+func (l *Language) Pointer() unsafe.Pointer {
+	return l.ptr
+}
+
+// Close unloads the shared library of a language from LoadDynamicLanguage
+// and drops its queries from the NewQuery cache. Parsers, trees and queries
+// of the language must not be used afterwards.
+// For other languages, and when called again, Close does nothing.
+func (l *Language) Close() error {
+	if l.lib == nil {
+		return nil
+	}
+	queries.evict(l.id())
+	return l.lib.close()
+}
+
+// id identifies the language for cache keys. Unlike its TSLanguage pointer,
+// which a library reopened after Close may reuse, it is never reused.
+func (l *Language) id() uint64 {
+	return l.serial
+}
+
+// Version returns the ABI version the grammar was generated with.
+func (l *Language) Version() uint32 {
+	return tsLanguageVersion(l)
+}
+
+// NodeKindCount returns the number of node kinds in the grammar, named and
+// anonymous. Kind ids run from 0 to NodeKindCount()-1.
+func (l *Language) NodeKindCount() int {
+	return int(l.ts.SymbolCount())
+}
+
+// NodeKindForID returns the name of the node kind with the given id, or ""
+// if there is none.
+func (l *Language) NodeKindForID(id uint16) string {
+	return tsSymbolName(l, id)
+}
+
+// IDForNodeKind returns the id of the named or anonymous node kind called
+// name, or 0 if the grammar has no such kind. Comparing ids is cheaper than
+// comparing kind names.
+func (l *Language) IDForNodeKind(name string, named bool) uint16 {
+	return tsSymbolForName(l, name, named)
+}
+
+// NodeKindIsNamed reports whether the node kind with the given id is named
+// rather than an anonymous token.
+func (l *Language) NodeKindIsNamed(id uint16) bool {
+	return l.ts.SymbolType(sitter.Symbol(id)) == sitter.SymbolTypeRegular
+}
+
+// FieldCount returns the number of field names in the grammar. Field ids run
+// from 1 to FieldCount().
+func (l *Language) FieldCount() int {
+	return int(tsFieldCount(l))
+}
+
+// FieldName returns the name of the field with the given id, or "" if there
+// is none.
+func (l *Language) FieldName(id uint16) string {
+	l.fieldsOnce.Do(func() {
+		l.fieldNames = make([]string, tsFieldCount(l)+1)
+		for i := 1; i < len(l.fieldNames); i++ {
+			l.fieldNames[i] = tsFieldName(l, uint16(i))
+		}
+	})
+	if int(id) >= len(l.fieldNames) {
+		return ""
+	}
+	return l.fieldNames[id]
+}
+
+// FieldIDForName returns the id of the field called name, or 0 if the
+// grammar has no such field.
+func (l *Language) FieldIDForName(name string) uint16 {
+	return tsFieldIDForName(l, name)
+}
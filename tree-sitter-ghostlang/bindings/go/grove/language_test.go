@@ -0,0 +1,81 @@
+package grove_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestLanguageMetadata(t *testing.T) {
+	lang := ghostlang(t)
+	if v := lang.Version(); v < 13 || v > 14 {
+		t.Errorf("Version() = %d, want an ABI go-tree-sitter supports", v)
+	}
+
+	id := lang.IDForNodeKind("function_declaration", true)
+	if id == 0 {
+		t.Fatalf("IDForNodeKind(function_declaration) = 0")
+	}
+	if got := lang.NodeKindForID(id); got != "function_declaration" {
+		t.Errorf("NodeKindForID(%d) = %q", id, got)
+	}
+	if !lang.NodeKindIsNamed(id) {
+		t.Errorf("function_declaration is not named")
+	}
+	kw := lang.IDForNodeKind("function", false)
+	if kw == 0 || lang.NodeKindIsNamed(kw) || lang.NodeKindForID(kw) != "function" {
+		t.Errorf("anonymous \"function\" token: id %d, named %v", kw, lang.NodeKindIsNamed(kw))
+	}
+	if got := lang.IDForNodeKind("no_such_kind", true); got != 0 {
+		t.Errorf("IDForNodeKind(no_such_kind) = %d, want 0", got)
+	}
+
+	kinds := make(map[string]bool)
+	for i := 0; i < lang.NodeKindCount(); i++ {
+		kinds[lang.NodeKindForID(uint16(i))] = true
+	}
+	for _, k := range []string{"source_file", "identifier", "comment", "{", "var"} {
+		if !kinds[k] {
+			t.Errorf("kind %q missing from NodeKindForID over NodeKindCount", k)
+		}
+	}
+
+	fields := make(map[string]uint16)
+	for i := 1; i <= lang.FieldCount(); i++ {
+		fields[lang.FieldName(uint16(i))] = uint16(i)
+	}
+	for _, f := range []string{"name", "body", "condition"} {
+		if fields[f] == 0 || lang.FieldIDForName(f) != fields[f] {
+			t.Errorf("field %q: FieldIDForName = %d, enumerated id %d", f, lang.FieldIDForName(f), fields[f])
+		}
+	}
+	if lang.FieldName(0) != "" || lang.FieldIDForName("nope") != 0 {
+		t.Errorf("unknown fields are not reported as empty")
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	if err := grove.CheckCompatible(ghostlang(t)); err != nil {
+		t.Errorf("CheckCompatible(ghostlang) = %v", err)
+	}
+
+	// A TSLanguage starts with its ABI version, which is all the check reads.
+	for _, version := range []uint32{grove.MinCompatibleLanguageVersion - 1, grove.LanguageVersion + 1} {
+		fake := make([]uint32, 64)
+		fake[0] = version
+		lang, err := grove.NewLanguage("fake", unsafe.Pointer(&fake[0]))
+		if lang != nil || !errors.Is(err, grove.ErrIncompatibleLanguage) {
+			t.Errorf("NewLanguage(version %d) = %v, %v; want ErrIncompatibleLanguage", version, lang, err)
+			continue
+		}
+		for _, want := range []string{"fake", fmt.Sprint(version), fmt.Sprintf("%d to %d", grove.MinCompatibleLanguageVersion, grove.LanguageVersion)} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q does not mention %q", err, want)
+			}
+		}
+	}
+}
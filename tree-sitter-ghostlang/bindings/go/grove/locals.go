@@ -0,0 +1,211 @@
+package grove
+
+import (
+	"sort"
+	"strings"
+)
+
+// LocalsResolver resolves variable references to their definitions with a
+// locals query. The query captures the nodes that open a lexical scope as
+// @local.scope, the names a scope binds as @local.definition or
+// @local.definition.<kind>, and the names that refer to a binding as
+// @local.reference. A definition belongs to the innermost scope containing
+// it. As in nvim-treesitter, a pattern that sets definition.<kind>.scope to
+// "parent" binds its @local.definition.<kind> captures in the scope around
+// that one instead, as for a function's own name.
+//
+// A reference resolves to the last definition of the same name before it in
+// the innermost enclosing scope that has one, so an inner definition shadows
+// an outer one for the rest of its scope.
+type LocalsResolver struct {
+	query *Query
+}
+
+// NewLocalsResolver returns a resolver for query.
+func NewLocalsResolver(query *Query) *LocalsResolver {
+	return &LocalsResolver{query: query}
+}
+
+// Definition returns the node defining the name that ref refers to, as
+// Analyze(tree).Definition(ref) does. To look up several nodes of one tree,
+// analyze it once.
+func (r *LocalsResolver) Definition(tree *Tree, ref *Node) (*Node, bool) {
+	return r.Analyze(tree).Definition(ref)
+}
+
+// References returns the references that resolve to def, as
+// Analyze(tree).References(def) does. To look up several nodes of one tree,
+// analyze it once.
+func (r *LocalsResolver) References(tree *Tree, def *Node) []*Node {
+	return r.Analyze(tree).References(def)
+}
+
+// Locals is the result of running a locals query over a tree, with every
+// reference resolved. It holds nodes of the tree, so it must not be used
+// once the tree is closed or edited.
+type Locals struct {
+	scopes      []*localScope // by start, outer scopes before inner ones
+	definitions map[uintptr]*localDefinition
+	resolved    map[uintptr]*localDefinition // by reference
+}
+
+// localScope is a @local.scope node and the definitions bound in it, by
+// name and in document order.
+type localScope struct {
+	node   *Node
+	parent *localScope
+	defs   map[string][]*localDefinition
+}
+
+type localDefinition struct {
+	node *Node
+	name string
+	refs []*Node // in document order
+}
+
+// Definition returns the node defining the name that ref refers to. Passed
+// a definition, it returns that definition. It reports false if ref is
+// neither or if the name is not defined in any enclosing scope.
+func (l *Locals) Definition(ref *Node) (*Node, bool) {
+	if d := l.definitions[ref.ID()]; d != nil {
+		return d.node, true
+	}
+	if d := l.resolved[ref.ID()]; d != nil {
+		return d.node, true
+	}
+	return nil, false
+}
+
+// References returns the references that resolve to def, in document
+// order, not including def itself. It returns nil if def is not a
+// definition.
+func (l *Locals) References(def *Node) []*Node {
+	if d := l.definitions[def.ID()]; d != nil {
+		return d.refs
+	}
+	return nil
+}
+
+// Analyze runs the resolver's query over tree and resolves every reference
+// in it. If tree is not in the query's language, the result is empty.
+func (r *LocalsResolver) Analyze(tree *Tree) *Locals {
+	l := &Locals{definitions: make(map[uintptr]*localDefinition), resolved: make(map[uintptr]*localDefinition)}
+	if tree.Language() != r.query.Language() {
+		return l
+	}
+	type definition struct {
+		node   *Node
+		parent bool
+	}
+	var defs []definition
+	var refs []*Node
+	seen := make(map[uintptr]bool)
+	matches := r.query.Matches(tree.RootNode())
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		for _, c := range m.Captures {
+			switch {
+			case c.Name == "local.scope":
+				if id := c.Node.ID(); !seen[id] {
+					seen[id] = true
+					l.scopes = append(l.scopes, &localScope{node: c.Node, defs: make(map[string][]*localDefinition)})
+				}
+			case c.Name == "local.definition" || strings.HasPrefix(c.Name, "local.definition."):
+				scope, _ := r.query.property(m.PatternIndex, strings.TrimPrefix(c.Name, "local.")+".scope")
+				defs = append(defs, definition{c.Node, scope == "parent"})
+			case c.Name == "local.reference":
+				refs = append(refs, c.Node)
+			}
+		}
+	}
+	sort.SliceStable(l.scopes, func(i, j int) bool {
+		a, b := l.scopes[i].node, l.scopes[j].node
+		if a.StartByte() != b.StartByte() {
+			return a.StartByte() < b.StartByte()
+		}
+		return a.EndByte() > b.EndByte()
+	})
+	var open []*localScope
+	for _, s := range l.scopes {
+		for len(open) > 0 && !contains(open[len(open)-1].node, s.node) {
+			open = open[:len(open)-1]
+		}
+		if len(open) > 0 {
+			s.parent = open[len(open)-1]
+		}
+		open = append(open, s)
+	}
+
+	sort.SliceStable(defs, func(i, j int) bool {
+		return defs[i].node.StartByte() < defs[j].node.StartByte()
+	})
+	next := l.scopeSweep()
+	for _, d := range defs {
+		s := next(d.node)
+		if l.definitions[d.node.ID()] != nil {
+			continue
+		}
+		def := &localDefinition{node: d.node, name: d.node.Utf8Text()}
+		l.definitions[d.node.ID()] = def
+		if d.parent && s != nil {
+			s = s.parent
+		}
+		if s != nil {
+			s.defs[def.name] = append(s.defs[def.name], def)
+		}
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].StartByte() < refs[j].StartByte()
+	})
+	next = l.scopeSweep()
+	for _, ref := range refs {
+		s := next(ref)
+		id := ref.ID()
+		if l.definitions[id] != nil || l.resolved[id] != nil {
+			continue
+		}
+		if d := resolve(s, ref); d != nil {
+			l.resolved[id] = d
+			d.refs = append(d.refs, ref)
+		}
+	}
+	return l
+}
+
+// scopeSweep returns a function reporting the innermost scope containing
+// each of a series of nodes, which must come in order of their start.
+func (l *Locals) scopeSweep() func(n *Node) *localScope {
+	var open []*localScope
+	i := 0
+	return func(n *Node) *localScope {
+		for ; i < len(l.scopes) && l.scopes[i].node.StartByte() <= n.StartByte(); i++ {
+			open = append(open, l.scopes[i])
+		}
+		for len(open) > 0 && !contains(open[len(open)-1].node, n) {
+			open = open[:len(open)-1]
+		}
+		if len(open) == 0 {
+			return nil
+		}
+		return open[len(open)-1]
+	}
+}
+
+// resolve returns the definition that ref, whose innermost scope is s,
+// refers to, or nil.
+func resolve(s *localScope, ref *Node) *localDefinition {
+	name := ref.Utf8Text()
+	for ; s != nil; s = s.parent {
+		defs := s.defs[name]
+		// The last definition starting at or before ref.
+		i := sort.Search(len(defs), func(i int) bool { return defs[i].node.StartByte() > ref.StartByte() })
+		if i > 0 {
+			return defs[i-1]
+		}
+	}
+	return nil
+}
+
+func contains(outer, inner *Node) bool {
+	return outer.StartByte() <= inner.StartByte() && inner.EndByte() <= outer.EndByte()
+}
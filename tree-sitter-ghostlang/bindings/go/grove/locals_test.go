@@ -0,0 +1,119 @@
+package grove_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func localsResolver(t *testing.T) *grove.LocalsResolver {
+	t.Helper()
+	source, err := os.ReadFile("../../../queries/locals.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(t), string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grove.NewLocalsResolver(q)
+}
+
+const localsSource = `var x = 1;
+function f(y) {
+  var z = x + y;
+  if (z > 0) {
+    var x = z;
+    print(x);
+  }
+  return x;
+}
+f(x);
+`
+
+// identifierAt returns the identifier at the n-th occurrence (from 0) of
+// marker, which must start with it.
+func identifierAt(t *testing.T, tree *grove.Tree, marker string, n int) *grove.Node {
+	t.Helper()
+	src := string(tree.Source())
+	at := -1
+	for i := 0; i <= n; i++ {
+		next := strings.Index(src[at+1:], marker)
+		if next < 0 {
+			t.Fatalf("no occurrence %d of %q", n, marker)
+		}
+		at += next + 1
+	}
+	node := tree.RootNode().NamedDescendantForByteRange(uint32(at), uint32(at+1))
+	if node.Kind() != "identifier" {
+		t.Fatalf("%q occurrence %d is a %s", marker, n, node.Kind())
+	}
+	return node
+}
+
+func TestLocalsDefinition(t *testing.T) {
+	tree := parse(t, localsSource)
+	defer tree.Close()
+	r := localsResolver(t)
+
+	outerX := identifierAt(t, tree, "x = 1", 0)
+	innerX := identifierAt(t, tree, "x = z", 0)
+	tests := []struct {
+		name string
+		ref  *grove.Node
+		def  *grove.Node
+	}{
+		{"outer variable in function", identifierAt(t, tree, "x + y", 0), outerX},
+		{"parameter", identifierAt(t, tree, "y;", 0), identifierAt(t, tree, "y)", 0)},
+		{"shadowing variable", identifierAt(t, tree, "x);", 0), innerX},
+		{"outer variable after the shadowing block", identifierAt(t, tree, "x;\n}", 0), outerX},
+		{"function called at top level", identifierAt(t, tree, "f(x)", 0), identifierAt(t, tree, "f(y)", 0)},
+		{"top-level argument", identifierAt(t, tree, "x);", 1), outerX},
+		{"definition itself", innerX, innerX},
+	}
+	for _, tt := range tests {
+		def, ok := r.Definition(tree, tt.ref)
+		if !ok {
+			t.Errorf("%s: no definition", tt.name)
+			continue
+		}
+		if def.StartByte() != tt.def.StartByte() {
+			t.Errorf("%s: resolved to %s at %d, want %d", tt.name, def.Utf8Text(), def.StartByte(), tt.def.StartByte())
+		}
+	}
+	if def, ok := r.Definition(tree, identifierAt(t, tree, "print", 0)); ok {
+		t.Errorf("undefined print resolved to %s at %d", def.Utf8Text(), def.StartByte())
+	}
+}
+
+func TestLocalsReferences(t *testing.T) {
+	tree := parse(t, localsSource+"var o = { x: 1 };\no.x = 2;\n")
+	defer tree.Close()
+	l := localsResolver(t).Analyze(tree)
+
+	var got []uint32
+	for _, ref := range l.References(identifierAt(t, tree, "x = 1", 0)) {
+		got = append(got, ref.StartByte())
+	}
+	src := string(tree.Source())
+	// Neither the inner x nor the object key and member property count.
+	want := []uint32{
+		uint32(strings.Index(src, "x + y")),
+		uint32(strings.Index(src, "x;\n}")),
+		uint32(strings.Index(src, "x);\nvar o")),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("References = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("References = %v, want %v", got, want)
+			break
+		}
+	}
+	if refs := l.References(identifierAt(t, tree, "x + y", 0)); refs != nil {
+		t.Errorf("References of a reference = %v, want nil", refs)
+	}
+}
@@ -0,0 +1,15 @@
+#include "api.h"
+#include "_cgo_export.h"
+
+static void grove_parser_log(void *payload, TSLogType type, const char *message) {
+	groveParserLog((uintptr_t)payload, type, (char *)message);
+}
+
+void grove_parser_set_logger(TSParser *parser, uintptr_t handle) {
+	TSLogger logger = {NULL, NULL};
+	if (handle != 0) {
+		logger.payload = (void *)handle;
+		logger.log = grove_parser_log;
+	}
+	ts_parser_set_logger(parser, logger);
+}
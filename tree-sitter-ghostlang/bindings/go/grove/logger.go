@@ -0,0 +1,60 @@
+package grove
+
+// #include <stdint.h>
+// #include "api.h"
+//
+// void grove_parser_set_logger(TSParser *parser, uintptr_t handle);
+import "C"
+
+import "runtime/cgo"
+
+// LogKind tells the two kinds of message tree-sitter logs while parsing.
+type LogKind int
+
+const (
+	// LogParse messages trace the parser: shifts, reductions, error
+	// recovery and subtree reuse.
+	LogParse LogKind = iota
+	// LogLex messages trace the lexer consuming and skipping characters.
+	LogLex
+)
+
+func (k LogKind) String() string {
+	if k == LogLex {
+		return "lex"
+	}
+	return "parse"
+}
+
+// SetLogger makes the parser call fn with each message tree-sitter logs
+// during later parses, on the goroutine calling Parse. It is meant for
+// debugging a grammar: logging slows parsing down considerably. A nil fn
+// turns logging off, and returning the parser to a ParserPool does too.
+func (p *Parser) SetLogger(fn func(kind LogKind, message string)) {
+	if p.closed {
+		panic(ErrClosed)
+	}
+	p.setLogger(fn)
+}
+
+func (p *Parser) setLogger(fn func(kind LogKind, message string)) {
+	old := p.logger
+	p.logger = 0
+	if fn != nil {
+		p.logger = cgo.NewHandle(fn)
+	}
+	C.grove_parser_set_logger(parserHandle(p.ts), C.uintptr_t(p.logger))
+	if old != 0 {
+		old.Delete()
+	}
+}
+
+//export groveParserLog
+func groveParserLog(handle C.uintptr_t, kind C.TSLogType, message *C.char) {
+	fn := cgo.Handle(handle).Value().(func(LogKind, string))
+	k := LogParse
+	if kind == C.TSLogTypeLex {
+		k = LogLex
+	}
+	fn(k, C.GoString(message))
+}
@@ -0,0 +1,67 @@
+package grove_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestParserLogger(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	counts := make(map[grove.LogKind]int)
+	p.SetLogger(func(kind grove.LogKind, message string) {
+		if message == "" {
+			t.Errorf("empty %s message", kind)
+		}
+		counts[kind]++
+	})
+	tree, err := p.Parse(context.Background(), []byte("var x = 1;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Close()
+	if counts[grove.LogParse] == 0 || counts[grove.LogLex] == 0 {
+		t.Errorf("logged %d parse and %d lex messages, want both", counts[grove.LogParse], counts[grove.LogLex])
+	}
+
+	p.SetLogger(nil)
+	before := counts[grove.LogParse] + counts[grove.LogLex]
+	tree, err = p.Parse(context.Background(), []byte("var y = 2;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Close()
+	if after := counts[grove.LogParse] + counts[grove.LogLex]; after != before {
+		t.Errorf("a nil logger still received %d messages", after-before)
+	}
+}
+
+func TestParserPoolClearsLogger(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	defer pool.Close()
+
+	p, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := 0
+	p.SetLogger(func(grove.LogKind, string) { logged++ })
+	pool.Put(p)
+
+	p, err = pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(p)
+	tree, err := p.Parse(context.Background(), []byte("var x = 1;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree.Close()
+	if logged != 0 {
+		t.Errorf("the previous user's logger received %d messages after Put", logged)
+	}
+}
@@ -0,0 +1,275 @@
+package grove
+
+import (
+	"fmt"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Node is a single node of a Tree. A Node is only valid while its tree is:
+// once the tree is closed, its methods panic with ErrClosed.
+type Node struct {
+	ts   *sitter.Node
+	tree *Tree
+}
+
+// Tree returns the tree the node belongs to.
+func (n *Node) Tree() *Tree {
+	return n.tree
+}
+
+// Kind returns the grammar type of the node, such as "function_declaration".
+func (n *Node) Kind() string {
+	return n.live().Type()
+}
+
+// KindID returns the numeric id of the node's kind, as used by
+// Language.NodeKindForID. Comparing ids avoids comparing kind strings.
+func (n *Node) KindID() uint16 {
+	return uint16(n.live().Symbol())
+}
+
+// IsNamed reports whether the node comes from a named grammar rule, such as
+// an identifier, rather than a literal token such as "var" or ";".
+func (n *Node) IsNamed() bool {
+	return n.live().IsNamed()
+}
+
+// IsMissing reports whether the parser inserted the node, with zero width,
+// to recover from a syntax error.
+func (n *Node) IsMissing() bool {
+	return n.live().IsMissing()
+}
+
+// IsExtra reports whether the node is one the grammar allows anywhere, such
+// as a comment, rather than one its rules ask for. An ERROR node holding
+// tokens the parser skipped to recover is an extra as well.
+func (n *Node) IsExtra() bool {
+	return n.live().IsExtra()
+}
+
+// IsError reports whether the node is an ERROR node, wrapping text the
+// parser could not fit into the grammar.
+func (n *Node) IsError() bool {
+	return n.live().IsError()
+}
+
+// HasError reports whether the node is, or contains, an ERROR or MISSING
+// node.
+func (n *Node) HasError() bool {
+	return n.live().HasError()
+}
+
+// StartByte returns the byte offset where the node starts.
+func (n *Node) StartByte() uint32 {
+	return n.live().StartByte()
+}
+
+// EndByte returns the byte offset where the node ends.
+func (n *Node) EndByte() uint32 {
+	return n.live().EndByte()
+}
+
+// ChildCount returns the number of children, named and anonymous.
+func (n *Node) ChildCount() int {
+	return int(n.live().ChildCount())
+}
+
+// Child returns the child at index i, or nil if there is none.
+func (n *Node) Child(i int) *Node {
+	return n.tree.node(n.live().Child(i))
+}
+
+// NamedChildCount returns the number of named children.
+func (n *Node) NamedChildCount() int {
+	return int(n.live().NamedChildCount())
+}
+
+// NamedChild returns the named child at index i, or nil if there is none.
+func (n *Node) NamedChild(i int) *Node {
+	return n.tree.node(n.live().NamedChild(i))
+}
+
+// Parent returns the node's parent, or nil for the root.
+func (n *Node) Parent() *Node {
+	return n.tree.node(n.live().Parent())
+}
+
+// NextSibling returns the next sibling, named or anonymous, or nil if n is
+// the last child.
+func (n *Node) NextSibling() *Node {
+	return n.tree.node(n.live().NextSibling())
+}
+
+// PrevSibling returns the previous sibling, named or anonymous, or nil if n
+// is the first child.
+func (n *Node) PrevSibling() *Node {
+	return n.tree.node(n.live().PrevSibling())
+}
+
+// NextNamedSibling returns the next named sibling, skipping anonymous
+// tokens, or nil if there is none.
+func (n *Node) NextNamedSibling() *Node {
+	return n.tree.node(n.live().NextNamedSibling())
+}
+
+// PrevNamedSibling returns the previous named sibling, skipping anonymous
+// tokens, or nil if there is none.
+func (n *Node) PrevNamedSibling() *Node {
+	return n.tree.node(n.live().PrevNamedSibling())
+}
+
+// Ancestors returns the nodes enclosing n, from its parent up to the root.
+func (n *Node) Ancestors() []*Node {
+	var ancestors []*Node
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// AncestorOfType returns the nearest ancestor of n, not counting n itself,
+// whose Kind is kind, or nil if there is none.
+func (n *Node) AncestorOfType(kind string) *Node {
+	for p := n.live().Parent(); p != nil; p = p.Parent() {
+		if p.Type() == kind {
+			return n.tree.node(p)
+		}
+	}
+	return nil
+}
+
+// ChildByFieldName returns the first child in the given grammar field, such
+// as "name" or "body", or nil if the node has none.
+func (n *Node) ChildByFieldName(field string) *Node {
+	return n.tree.node(n.live().ChildByFieldName(field))
+}
+
+// ChildrenByFieldName returns every child in the given grammar field, in
+// order. It returns nil if the node has none.
+func (n *Node) ChildrenByFieldName(field string) []*Node {
+	var children []*Node
+	n.eachChild(func(_ int, c *sitter.Node, name string) bool {
+		if name == field {
+			children = append(children, n.tree.node(c))
+		}
+		return true
+	})
+	return children
+}
+
+// FieldNameForChild returns the grammar field of the child at index i, or ""
+// if the child is in no field or there is no such child.
+func (n *Node) FieldNameForChild(i int) string {
+	var field string
+	n.eachChild(func(j int, _ *sitter.Node, name string) bool {
+		if j == i {
+			field = name
+			return false
+		}
+		return true
+	})
+	return field
+}
+
+// eachChild calls fn with the index, node and field name of each child of n
+// until fn returns false. A cursor reads each child's field as it goes, where
+// looking fields up by index would make a full scan quadratic.
+func (n *Node) eachChild(fn func(i int, c *sitter.Node, field string) bool) {
+	c := sitter.NewTreeCursor(n.live())
+	defer c.Close()
+	if !c.GoToFirstChild() {
+		return
+	}
+	for i := 0; ; i++ {
+		if !fn(i, c.CurrentNode(), c.CurrentFieldName()) || !c.GoToNextSibling() {
+			return
+		}
+	}
+}
+
+// StartPoint returns the row/column position where the node starts, with the
+// column in the tree's ColumnEncoding.
+func (n *Node) StartPoint() Point {
+	return n.tree.encodePoint(n.live().StartPoint(), n.StartByte())
+}
+
+// EndPoint returns the row/column position where the node ends, with the
+// column in the tree's ColumnEncoding.
+func (n *Node) EndPoint() Point {
+	return n.tree.encodePoint(n.live().EndPoint(), n.EndByte())
+}
+
+// ID returns an identifier for the syntax node, unique among the nodes of
+// its tree while the tree is open, for use as a map key. Every *Node for the
+// same syntax node has the same ID, however it was reached.
+//
+// IDs come from tree-sitter's internal node addresses. After an edit and
+// ParseIncremental, a node the parser reused from the old tree keeps its ID
+// in the new one; a node inside or next to an edited region, and every
+// ancestor of one, is rebuilt and gets a new ID, as does the rest of a
+// statement whose wrapper was re-reduced. Reuse is an optimisation, so a
+// different ID does not mean the node changed. The IDs of a closed tree may
+// be reused by trees allocated later, and a Copy shares its IDs with the
+// original.
+func (n *Node) ID() uintptr {
+	return tsNodeID(n.live())
+}
+
+// Equal reports whether n and other are the same syntax node of the same
+// tree. Unlike comparing IDs, it tells the nodes of a tree from those of its
+// copies.
+func (n *Node) Equal(other *Node) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	return tsNodeEq(n.live(), other.live())
+}
+
+// live returns the tree-sitter node, panicking if its tree is closed.
+func (n *Node) live() *sitter.Node {
+	if n.tree.closed {
+		panic(ErrClosed)
+	}
+	return n.ts
+}
+
+// DescendantForByteRange returns the smallest node within n that spans the
+// byte range [start, end], or nil if the range is not within n. For an empty
+// range between two tokens, the node starting at the offset is preferred.
+func (n *Node) DescendantForByteRange(start, end uint32) *Node {
+	return n.descendantForByteRange(start, end, false)
+}
+
+// NamedDescendantForByteRange is like DescendantForByteRange but only
+// returns named nodes.
+func (n *Node) NamedDescendantForByteRange(start, end uint32) *Node {
+	return n.descendantForByteRange(start, end, true)
+}
+
+func (n *Node) descendantForByteRange(start, end uint32, named bool) *Node {
+	if start > end || start < n.StartByte() || end > n.EndByte() {
+		return nil
+	}
+	return n.tree.node(tsDescendantForByteRange(n.live(), start, end, named))
+}
+
+// Text returns the node's source text, sliced from the source its tree was
+// parsed from. In a tree parsed with included ranges, a node spanning the
+// gap between two ranges gets the text of its parts in the ranges, joined
+// into a new slice; the text in the gap was never parsed. After Tree.Edit
+// and before re-parsing, node ranges may no longer line up with that source
+// and the text can be stale. Text panics if the node extends past the end of
+// the source rather than return bytes that belong to nothing.
+func (n *Node) Text() []byte {
+	start, end := n.StartByte(), n.EndByte()
+	if int(end) > len(n.tree.src) {
+		panic(fmt.Sprintf("grove: node [%d, %d) extends past the end of its %d-byte source", start, end, len(n.tree.src)))
+	}
+	return n.tree.text(start, end)
+}
+
+// Utf8Text returns Text as a string.
+func (n *Node) Utf8Text() string {
+	return string(n.Text())
+}
@@ -0,0 +1,250 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestDescendantForByteRange(t *testing.T) {
+	src := "var total = a+b;"
+	tree := parse(t, src)
+	root := tree.RootNode()
+	text := func(start, end uint32) string { return src[start:end] }
+
+	n := root.DescendantForByteRange(4, 7)
+	if n == nil || n.Kind() != "identifier" || text(n.StartByte(), n.EndByte()) != "total" {
+		t.Fatalf("DescendantForByteRange(4, 7) = %v", n)
+	}
+	n = root.NamedDescendantForByteRange(12, 15)
+	if n == nil || n.Kind() != "additive_expression" {
+		t.Errorf("NamedDescendantForByteRange(12, 15) = %v", n)
+	}
+	// "a+b": offset 13 is between "a" and "+".
+	if n := tree.NodeAt(13); n == nil || n.Kind() != "+" {
+		t.Errorf("NodeAt(13) = %v, want the + token", n)
+	}
+	if n := tree.NodeAt(12); n == nil || n.Kind() != "identifier" {
+		t.Errorf("NodeAt(12) = %v, want identifier", n)
+	}
+	if n := tree.NodeAt(99); n != nil {
+		t.Errorf("NodeAt past the end = %v, want nil", n.Kind())
+	}
+	if n := root.DescendantForByteRange(10, 99); n != nil {
+		t.Errorf("range past the end = %v, want nil", n.Kind())
+	}
+}
+
+func TestNodeText(t *testing.T) {
+	tree := parse(t, "var greeting = \"héllo\";")
+	decl := tree.RootNode().NamedChild(0).NamedChild(0)
+	if got := decl.Utf8Text(); got != "var greeting = \"héllo\";" {
+		t.Errorf("Utf8Text() = %q", got)
+	}
+	name := decl.NamedChild(0)
+	if got := string(name.Text()); got != "greeting" {
+		t.Errorf("Text() = %q, want greeting", got)
+	}
+}
+
+func TestNodeTextPastSourcePanics(t *testing.T) {
+	src := "var greeting = 1;"
+	tree := parse(t, src)
+	// Edit the tree as if the name grew but keep the old source, so the
+	// tree now claims more text than the source has.
+	_, edit := replace(src, 4, 12, "greetingsAndSalutations")
+	tree.Edit(edit)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Text() on a node past the end of the source did not panic")
+		}
+	}()
+	tree.RootNode().Text()
+}
+
+func TestChildByFieldName(t *testing.T) {
+	src := "function greet(name) { return name; }\nif (a) b(); else c();\n"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	fn := tree.RootNode().NamedChild(0).NamedChild(0)
+	if got := fn.ChildByFieldName("name"); got == nil || got.Utf8Text() != "greet" {
+		t.Errorf("ChildByFieldName(name) = %v, want greet", got)
+	}
+	if got := fn.ChildByFieldName("body"); got == nil || got.Kind() != "block_statement" {
+		t.Errorf("ChildByFieldName(body) = %v, want a block_statement", got)
+	}
+	if got := fn.ChildByFieldName("condition"); got != nil {
+		t.Errorf("ChildByFieldName(condition) = %v, want nil", got)
+	}
+
+	var fields []string
+	for i := 0; i < fn.ChildCount(); i++ {
+		fields = append(fields, fn.FieldNameForChild(i))
+	}
+	if got, want := strings.Join(fields, ","), ",name,parameters,body"; got != want {
+		t.Errorf("field names = %q, want %q", got, want)
+	}
+	if got := fn.FieldNameForChild(fn.ChildCount()); got != "" {
+		t.Errorf("FieldNameForChild past the end = %q", got)
+	}
+
+	stmt := tree.RootNode().NamedChild(1).NamedChild(0)
+	for field, want := range map[string]string{"condition": "a", "then": "b();", "else": "c();"} {
+		children := stmt.ChildrenByFieldName(field)
+		if len(children) != 1 || children[0].Utf8Text() != want {
+			t.Errorf("ChildrenByFieldName(%s) = %v, want [%s]", field, children, want)
+		}
+	}
+	if got := stmt.ChildrenByFieldName("name"); got != nil {
+		t.Errorf("ChildrenByFieldName(name) = %v, want nil", got)
+	}
+}
+
+func TestNodeNavigation(t *testing.T) {
+	src := "function outer(a, b) { var x = a + b; return x; }"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	at := uint32(strings.Index(src, "a +"))
+	ident := tree.RootNode().NamedDescendantForByteRange(at, at+1)
+	if ident == nil || ident.Kind() != "identifier" {
+		t.Fatalf("no identifier at %d: %v", at, ident)
+	}
+	fn := ident.AncestorOfType("function_declaration")
+	if fn == nil || fn.ChildByFieldName("name").Utf8Text() != "outer" {
+		t.Fatalf("AncestorOfType(function_declaration) = %v, want outer", fn)
+	}
+	if got := ident.AncestorOfType("class_declaration"); got != nil {
+		t.Errorf("AncestorOfType(class_declaration) = %v, want nil", got)
+	}
+	if got := fn.AncestorOfType("function_declaration"); got != nil {
+		t.Errorf("AncestorOfType counted the node itself")
+	}
+
+	ancestors := ident.Ancestors()
+	if len(ancestors) == 0 || ancestors[len(ancestors)-1].Kind() != "source_file" {
+		t.Fatalf("Ancestors does not end at the root: %v", ancestors)
+	}
+	if ancestors[0].ID() != ident.Parent().ID() {
+		t.Errorf("Ancestors does not start at the parent")
+	}
+	if tree.RootNode().Parent() != nil {
+		t.Errorf("root has a parent")
+	}
+
+	params := fn.ChildByFieldName("parameters")
+	a := params.NamedChild(0)
+	if got := a.NextSibling(); got == nil || got.Kind() != "," {
+		t.Errorf("NextSibling = %v, want the comma", got)
+	}
+	b := a.NextNamedSibling()
+	if b == nil || b.Utf8Text() != "b" {
+		t.Fatalf("NextNamedSibling = %v, want b", b)
+	}
+	if got := b.PrevNamedSibling(); got == nil || got.Utf8Text() != "a" {
+		t.Errorf("PrevNamedSibling = %v, want a", got)
+	}
+	if got := b.PrevSibling(); got == nil || got.Kind() != "," {
+		t.Errorf("PrevSibling = %v, want the comma", got)
+	}
+	if got := b.NextNamedSibling(); got != nil {
+		t.Errorf("NextNamedSibling of the last parameter = %v, want nil", got)
+	}
+}
+
+func TestNodeEqualAndID(t *testing.T) {
+	tree := parse(t, "var x = 1;\n")
+	defer tree.Close()
+	root := tree.RootNode()
+
+	ident := root.NamedDescendantForByteRange(4, 5)
+	viaFields := root.NamedChild(0).NamedChild(0).ChildByFieldName("name")
+	if !ident.Equal(viaFields) || ident.ID() != viaFields.ID() {
+		t.Errorf("the same identifier reached two ways is not equal")
+	}
+	if ident.Equal(ident.Parent()) || ident.ID() == ident.Parent().ID() {
+		t.Errorf("identifier equals its parent")
+	}
+	if ident.Equal(nil) {
+		t.Errorf("node equals nil")
+	}
+
+	// The single-child chain from expression down to number_literal spans
+	// the same bytes at every level, yet each node has its own ID.
+	seen := make(map[uintptr]string)
+	grove.Walk(root, func(n *grove.Node) bool {
+		if kind, dup := seen[n.ID()]; dup {
+			t.Errorf("%s and %s share ID %#x", kind, n.Kind(), n.ID())
+		}
+		seen[n.ID()] = n.Kind()
+		return true
+	})
+
+	copied := tree.Copy()
+	defer copied.Close()
+	other := copied.RootNode().NamedDescendantForByteRange(4, 5)
+	if other.ID() != ident.ID() || other.Equal(ident) {
+		t.Errorf("a copy's node should share the ID but not be Equal")
+	}
+}
+
+func TestNodeFlags(t *testing.T) {
+	// A comment (an extra), an error region around the stray b, and a
+	// missing semicolon at the end.
+	tree := parse(t, "// note\nf(a b);\nvar x = 1")
+	defer tree.Close()
+
+	find := func(match func(n *grove.Node) bool) *grove.Node {
+		t.Helper()
+		var found *grove.Node
+		grove.Walk(tree.RootNode(), func(n *grove.Node) bool {
+			if found == nil && match(n) {
+				found = n
+			}
+			return found == nil
+		})
+		if found == nil {
+			t.Fatalf("no such node in %s", tree)
+		}
+		return found
+	}
+	comment := find((*grove.Node).IsExtra)
+	errNode := find((*grove.Node).IsError)
+	missing := find((*grove.Node).IsMissing)
+	semicolon := find(func(n *grove.Node) bool { return n.Kind() == ";" && !n.IsMissing() })
+
+	type flags struct{ named, missing, extra, isError, hasError bool }
+	of := func(n *grove.Node) flags {
+		return flags{n.IsNamed(), n.IsMissing(), n.IsExtra(), n.IsError(), n.HasError()}
+	}
+	for _, tt := range []struct {
+		name string
+		node *grove.Node
+		kind string
+		want flags
+	}{
+		{"comment", comment, "comment", flags{named: true, extra: true}},
+		// Error recovery skipped over b, which makes its ERROR an extra.
+		{"error", errNode, "ERROR", flags{named: true, extra: true, isError: true, hasError: true}},
+		{"missing", missing, ";", flags{missing: true, hasError: true}},
+		{"semicolon", semicolon, ";", flags{}},
+		{"root", tree.RootNode(), "source_file", flags{named: true, hasError: true}},
+	} {
+		if got := tt.node.Kind(); got != tt.kind {
+			t.Errorf("%s: Kind() = %q, want %q", tt.name, got, tt.kind)
+		}
+		if got := of(tt.node); got != tt.want {
+			t.Errorf("%s: flags = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	lang := ghostlang(t)
+	if got := lang.NodeKindForID(comment.KindID()); got != "comment" {
+		t.Errorf("NodeKindForID(comment.KindID()) = %q", got)
+	}
+	if missing.KindID() != semicolon.KindID() || missing.KindID() != lang.IDForNodeKind(";", false) {
+		t.Errorf("the missing and present semicolons have kind ids %d and %d", missing.KindID(), semicolon.KindID())
+	}
+}
@@ -0,0 +1,198 @@
+package grove
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"slices"
+	"time"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Parser turns source text into syntax trees for a single language.
+//
+// A Parser holds mutable C state and is not safe for concurrent use. Use one
+// parser per goroutine, or share parsers through a ParserPool. Close frees
+// the C state, after which methods return ErrClosed; a parser that is never
+// closed is freed when it is garbage collected.
+type Parser struct {
+	lang    *Language
+	ts      *sitter.Parser
+	columns ColumnEncoding
+	poll    time.Duration
+	ranges  []Range    // set with SetIncludedRanges, or nil
+	logger  cgo.Handle // of the SetLogger function, or 0
+	closed  bool
+}
+
+// ParserOption configures a Parser at construction.
+type ParserOption func(*Parser)
+
+// WithColumnEncoding makes trees from the parser report Point columns in enc
+// instead of bytes.
+func WithColumnEncoding(enc ColumnEncoding) ParserOption {
+	return func(p *Parser) { p.columns = enc }
+}
+
+// WithPollInterval makes the parser check its context every d of parsing
+// time instead of watching it from a separate goroutine. Cancellation is then
+// noticed up to d late, but no goroutine is started per parse.
+func WithPollInterval(d time.Duration) ParserOption {
+	return func(p *Parser) { p.poll = d }
+}
+
+// NewParser returns a parser configured for lang. Call Close when done with
+// it to release the underlying tree-sitter parser. It fails if
+// CheckCompatible rejects lang.
+func NewParser(lang *Language, opts ...ParserOption) (*Parser, error) {
+	if err := CheckCompatible(lang); err != nil {
+		return nil, err
+	}
+	ts := sitter.NewParser()
+	ts.SetLanguage(lang.ts)
+	p := &Parser{lang: lang, ts: ts}
+	for _, opt := range opts {
+		opt(p)
+	}
+	runtime.SetFinalizer(p, (*Parser).Close)
+	return p, nil
+}
+
+// Language returns the language the parser was created for.
+func (p *Parser) Language() *Language {
+	return p.lang
+}
+
+// Parse parses src and returns its syntax tree. The returned tree retains
+// src, so the caller must not modify it afterwards.
+//
+// Parse honours ctx: when it is cancelled or its deadline passes, parsing
+// stops and Parse returns ctx.Err() and a nil tree. A context that is already
+// done returns immediately.
+func (p *Parser) Parse(ctx context.Context, src []byte) (*Tree, error) {
+	return p.parse(ctx, nil, src)
+}
+
+// ParseIncremental parses src reusing the unchanged parts of oldTree, which
+// must already reflect every edit made to its source through Tree.Edit. It
+// honours ctx like Parse.
+func (p *Parser) ParseIncremental(ctx context.Context, oldTree *Tree, src []byte) (*Tree, error) {
+	if oldTree.closed {
+		return nil, ErrClosed
+	}
+	return p.parse(ctx, oldTree.ts, src)
+}
+
+func (p *Parser) parse(ctx context.Context, old *sitter.Tree, src []byte) (*Tree, error) {
+	if p.closed {
+		return nil, ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Drop a cancellation that arrived as the previous parse finished.
+	tsParserClearCancel(p.ts)
+	var ts *sitter.Tree
+	var err error
+	if p.poll > 0 && ctx.Done() != nil {
+		ts, err = p.parsePolling(ctx, p.poll, func() (*sitter.Tree, error) {
+			return p.ts.ParseCtx(context.Background(), old, src)
+		})
+	} else {
+		ts, err = p.ts.ParseCtx(ctx, old, src)
+	}
+	if err != nil {
+		// The parser would otherwise resume the interrupted parse on its
+		// next call, even for unrelated input.
+		p.Reset()
+		return nil, err
+	}
+	return newTree(ts, p.lang, src, p.columns, p.ranges), nil
+}
+
+// parsePolling runs parse in slices of interval, checking ctx between
+// slices. tree-sitter resumes a parse that stopped at its timeout when it is
+// called again with the same input.
+func (p *Parser) parsePolling(ctx context.Context, interval time.Duration, parse func() (*sitter.Tree, error)) (*sitter.Tree, error) {
+	p.ts.SetOperationLimit(int(max(interval.Microseconds(), 1)))
+	defer p.ts.SetOperationLimit(0)
+	for {
+		ts, err := parse()
+		if !errors.Is(err, sitter.ErrOperationLimit) {
+			return ts, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// SetIncludedRanges restricts later parses to ranges of the source, for
+// languages embedded in another document. Text between the ranges is skipped
+// as though absent, while node positions stay absolute offsets into the whole
+// document. The ranges must be in order and must not overlap. A nil or empty
+// slice includes the whole document again. Trees report the ranges they were
+// parsed with through Tree.IncludedRanges.
+func (p *Parser) SetIncludedRanges(ranges []Range) error {
+	if p.closed {
+		return ErrClosed
+	}
+	if len(ranges) == 0 {
+		p.ts.SetIncludedRanges([]sitter.Range{fullRange})
+		p.ranges = nil
+		return nil
+	}
+	ts := make([]sitter.Range, len(ranges))
+	for i, r := range ranges {
+		if r.StartByte > r.EndByte {
+			return fmt.Errorf("grove: included range %d starts at byte %d after its end %d", i, r.StartByte, r.EndByte)
+		}
+		if i > 0 && r.StartByte < ranges[i-1].EndByte {
+			return fmt.Errorf("grove: included range %d starts at byte %d before the end of range %d", i, r.StartByte, i-1)
+		}
+		ts[i] = r.sitter()
+	}
+	p.ts.SetIncludedRanges(ts)
+	p.ranges = slices.Clone(ranges)
+	return nil
+}
+
+// Reset discards the state the parser keeps between parses, so that the
+// next parse starts from scratch rather than resuming an interrupted one.
+// Parse already does this when its context stops it; Reset is for a parser
+// whose history is unknown. It keeps the parser's settings, and returning a
+// parser to a ParserPool resets both. Reset panics with ErrClosed on a closed
+// parser.
+func (p *Parser) Reset() {
+	if p.closed {
+		panic(ErrClosed)
+	}
+	p.ts.Reset()
+	tsParserClearCancel(p.ts)
+}
+
+// resetSettings restores the configuration a fresh parser would have.
+func (p *Parser) resetSettings() {
+	p.ts.SetLanguage(p.lang.ts)
+	p.ts.SetOperationLimit(0)
+	p.ts.SetIncludedRanges([]sitter.Range{fullRange})
+	p.ranges = nil
+	if p.logger != 0 {
+		p.setLogger(nil)
+	}
+}
+
+// Close releases the underlying tree-sitter parser. Trees produced by the
+// parser remain valid. Calling Close again does nothing.
+func (p *Parser) Close() {
+	if !p.closed {
+		p.closed = true
+		if p.logger != 0 {
+			p.setLogger(nil)
+		}
+		p.ts.Close()
+	}
+}
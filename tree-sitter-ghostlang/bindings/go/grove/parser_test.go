@@ -0,0 +1,314 @@
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func ghostlang(t testing.TB) *grove.Language {
+	t.Helper()
+	lang, ok := grove.Get("ghostlang")
+	if !ok {
+		t.Fatalf("ghostlang is not registered")
+	}
+	return lang
+}
+
+func newParser(t testing.TB, lang *grove.Language, opts ...grove.ParserOption) *grove.Parser {
+	t.Helper()
+	p, err := grove.NewParser(lang, opts...)
+	if err != nil {
+		t.Fatalf("NewParser: %v", err)
+	}
+	return p
+}
+
+func parse(t testing.TB, src string) *grove.Tree {
+	t.Helper()
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return tree
+}
+
+func TestParse(t *testing.T) {
+	src := "var x = 5;\nfunction greet(name) { return name; }\n"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if root.Kind() != "source_file" {
+		t.Fatalf("root kind = %q, want source_file", root.Kind())
+	}
+	if root.NamedChildCount() != 2 {
+		t.Errorf("root has %d named children, want 2", root.NamedChildCount())
+	}
+	if got := root.NamedChild(1).NamedChild(0).Kind(); got != "function_declaration" {
+		t.Errorf("second statement is %q, want function_declaration", got)
+	}
+	if string(tree.Source()) != src {
+		t.Errorf("tree does not retain its source")
+	}
+	if tree.Language() != ghostlang(t) {
+		t.Errorf("tree language mismatch")
+	}
+}
+
+func largeSource(statements int) []byte {
+	var b strings.Builder
+	for i := 0; i < statements; i++ {
+		fmt.Fprintf(&b, "function f%d(a, b) { var c = a * (b + %d); if (c > 1) { return notify(\"x\"); } }\n", i, i)
+	}
+	return []byte(b.String())
+}
+
+func TestParseCancelledContext(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	src := []byte("var x = 5;")
+	allocs := testing.AllocsPerRun(10, func() {
+		tree, err := p.Parse(ctx, src)
+		if tree != nil || !errors.Is(err, context.Canceled) {
+			t.Fatalf("Parse = %v, %v; want nil, context.Canceled", tree, err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Parse with a cancelled context allocated %v times", allocs)
+	}
+}
+
+func TestParseDeadline(t *testing.T) {
+	src := largeSource(20000)
+	for name, opts := range map[string][]grove.ParserOption{
+		"watch": nil,
+		"poll":  {grove.WithPollInterval(time.Millisecond)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := newParser(t, ghostlang(t), opts...)
+			defer p.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+			defer cancel()
+			start := time.Now()
+			tree, err := p.Parse(ctx, src)
+			if tree != nil || !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Parse = %v, %v; want nil, context.DeadlineExceeded", tree, err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Parse took %v to notice the deadline", elapsed)
+			}
+
+			// The interrupted parse must not leak into the next one.
+			tree, err = p.Parse(context.Background(), []byte("var x = 5;"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tree.HasError() || tree.RootNode().EndByte() != 10 {
+				t.Errorf("parse after a timeout produced %s", tree)
+			}
+		})
+	}
+}
+
+func TestIncludedRangesText(t *testing.T) {
+	src := "<p>a</p>\n<% function f() { %>\n<p>b</p>\n<% var y = 2; } %>\n"
+	region := func(code string) grove.Range {
+		start := strings.Index(src, code)
+		end := start + len(code)
+		return grove.Range{StartByte: uint32(start), EndByte: uint32(end), StartPoint: pointAt(src, start), EndPoint: pointAt(src, end)}
+	}
+	ranges := []grove.Range{region("function f() {"), region("var y = 2; }")}
+
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	if err := p.SetIncludedRanges(ranges); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+	if tree.HasError() {
+		t.Fatalf("tree has errors: %s", tree)
+	}
+	if got := tree.IncludedRanges(); len(got) != 2 || got[0] != ranges[0] || got[1] != ranges[1] {
+		t.Errorf("IncludedRanges = %v, want %v", got, ranges)
+	}
+
+	// The declaration starts in the second region, at its offset in the
+	// whole source.
+	decl := tree.RootNode().DescendantForByteRange(ranges[1].StartByte, ranges[1].StartByte+3)
+	for decl.Kind() != "variable_declaration" {
+		decl = decl.Parent()
+	}
+	if decl.StartByte() != ranges[1].StartByte {
+		t.Errorf("declaration starts at %d, want %d", decl.StartByte(), ranges[1].StartByte)
+	}
+	if got := decl.Utf8Text(); got != "var y = 2;" {
+		t.Errorf("declaration text = %q", got)
+	}
+	if got := tree.PointForByte(decl.StartByte()); got != decl.StartPoint() {
+		t.Errorf("PointForByte(%d) = %v, want %v", decl.StartByte(), got, decl.StartPoint())
+	}
+
+	// The function spans the gap, whose text is left out.
+	fn := decl.Parent()
+	for fn.Kind() != "function_declaration" {
+		fn = fn.Parent()
+	}
+	if got, want := fn.Utf8Text(), "function f() {var y = 2; }"; got != want {
+		t.Errorf("function text = %q, want %q", got, want)
+	}
+
+	p.SetIncludedRanges(nil)
+	whole, err := p.Parse(context.Background(), []byte("var x = 1;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer whole.Close()
+	if whole.IncludedRanges() != nil {
+		t.Errorf("IncludedRanges of a whole-source tree = %v", whole.IncludedRanges())
+	}
+}
+
+func TestParserReset(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	src := []byte("var x = 5;\nfunction f(a) { return a; }\n")
+	fresh, err := p.Parse(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fresh.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := p.Parse(ctx, largeSource(20000)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Parse = %v, want context.DeadlineExceeded", err)
+	}
+	p.Reset()
+	tree, err := p.Parse(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+	if tree.String() != fresh.String() {
+		t.Errorf("parse after Reset produced\n%s\nwant\n%s", tree, fresh)
+	}
+}
+
+func TestParseAfterLateCancel(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	// Cancel the context during a parse too short to notice, so that the
+	// parse succeeds with the cancellation still pending.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.SetLogger(func(grove.LogKind, string) {
+		if ctx.Err() == nil {
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	tree, err := p.Parse(ctx, []byte("var x = 5;"))
+	if err != nil {
+		t.Skipf("the cancellation stopped the parse: %v", err)
+	}
+	tree.Close()
+	p.SetLogger(nil)
+
+	tree, err = p.Parse(context.Background(), largeSource(200))
+	if err != nil {
+		t.Fatalf("Parse after a late cancellation = %v", err)
+	}
+	defer tree.Close()
+	if tree.HasError() {
+		t.Errorf("parse after a late cancellation has errors")
+	}
+}
+
+func TestSetIncludedRanges(t *testing.T) {
+	src := "<p>intro</p>\n<% var a = 1; %>\n<p>var ignored = 2;</p>\n<% var b = a; %>\n"
+	code := func(marker string) grove.Range {
+		start := strings.Index(src, marker)
+		end := start + len(marker)
+		return grove.Range{
+			StartByte:  uint32(start),
+			EndByte:    uint32(end),
+			StartPoint: grove.Point{Row: uint32(strings.Count(src[:start], "\n")), Column: uint32(start - strings.LastIndex(src[:start], "\n") - 1)},
+			EndPoint:   grove.Point{Row: uint32(strings.Count(src[:end], "\n")), Column: uint32(end - strings.LastIndex(src[:end], "\n") - 1)},
+		}
+	}
+	ranges := []grove.Range{code("var a = 1;"), code("var b = a;")}
+
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	if err := p.SetIncludedRanges(ranges); err != nil {
+		t.Fatal(err)
+	}
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+
+	root := tree.RootNode()
+	if tree.HasError() {
+		t.Fatalf("tree has errors: %s", tree)
+	}
+	if root.NamedChildCount() != 2 {
+		t.Fatalf("root has %d named children, want 2: %s", root.NamedChildCount(), tree)
+	}
+	for i, r := range ranges {
+		stmt := root.NamedChild(i)
+		if stmt.StartByte() != r.StartByte || stmt.EndByte() != r.EndByte {
+			t.Errorf("statement %d spans [%d, %d), want [%d, %d)", i, stmt.StartByte(), stmt.EndByte(), r.StartByte, r.EndByte)
+		}
+		if stmt.StartPoint() != r.StartPoint {
+			t.Errorf("statement %d starts at %v, want %v", i, stmt.StartPoint(), r.StartPoint)
+		}
+	}
+	grove.WalkNamed(root, func(n *grove.Node) bool {
+		if n.Kind() == "source_file" {
+			return true
+		}
+		inside := false
+		for _, r := range ranges {
+			inside = inside || n.StartByte() >= r.StartByte && n.EndByte() <= r.EndByte
+		}
+		if !inside {
+			t.Errorf("%s [%d, %d) lies outside the included ranges", n.Kind(), n.StartByte(), n.EndByte())
+		}
+		return true
+	})
+
+	if err := p.SetIncludedRanges(nil); err != nil {
+		t.Fatal(err)
+	}
+	full, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer full.Close()
+	if !full.HasError() {
+		t.Errorf("parsing the whole template succeeded; included ranges were not reset")
+	}
+
+	if err := p.SetIncludedRanges([]grove.Range{ranges[1], ranges[0]}); err == nil {
+		t.Errorf("SetIncludedRanges accepted out-of-order ranges")
+	}
+}
@@ -0,0 +1,189 @@
+package grove
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Point is a row/column position in source text. Rows and columns are
+// zero-based. Columns count bytes unless the tree was parsed with another
+// ColumnEncoding.
+type Point struct {
+	Row    uint32
+	Column uint32
+}
+
+// Point16 is a row/column position whose column counts UTF-16 code units, as
+// used by the Language Server Protocol.
+type Point16 struct {
+	Row    uint32
+	Column uint32
+}
+
+// ColumnEncoding selects the unit Point columns are measured in.
+type ColumnEncoding int
+
+const (
+	// ColumnBytes counts UTF-8 bytes, tree-sitter's native unit.
+	ColumnBytes ColumnEncoding = iota
+	// ColumnUTF16 counts UTF-16 code units; runes outside the Basic
+	// Multilingual Plane count as two.
+	ColumnUTF16
+	// ColumnRunes counts Unicode code points.
+	ColumnRunes
+)
+
+func pointFromSitter(p sitter.Point) Point {
+	return Point{Row: p.Row, Column: p.Column}
+}
+
+// lineIndex records where each line of a source text starts.
+type lineIndex struct {
+	src    []byte
+	starts []uint32
+}
+
+func newLineIndex(src []byte) *lineIndex {
+	starts := []uint32{0}
+	for i, b := range src {
+		if b == '\n' {
+			starts = append(starts, uint32(i+1))
+		}
+	}
+	return &lineIndex{src: src, starts: starts}
+}
+
+// lineBounds returns the start of row and the end of its content, which
+// excludes the "\n" or "\r\n" terminator.
+func (ix *lineIndex) lineBounds(row int) (start, end uint32) {
+	start = ix.starts[row]
+	end = uint32(len(ix.src))
+	if row+1 < len(ix.starts) {
+		end = ix.starts[row+1] - 1
+		if end > start && ix.src[end-1] == '\r' {
+			end--
+		}
+	}
+	return start, end
+}
+
+// row returns the line containing offset.
+func (ix *lineIndex) row(offset uint32) int {
+	return sort.Search(len(ix.starts), func(i int) bool { return ix.starts[i] > offset }) - 1
+}
+
+// runeStart moves offset back to the first byte of the rune containing it,
+// without crossing lineStart.
+func (ix *lineIndex) runeStart(offset, lineStart uint32) uint32 {
+	for offset > lineStart && int(offset) < len(ix.src) && !utf8.RuneStart(ix.src[offset]) {
+		offset--
+	}
+	return offset
+}
+
+// columnOf measures the text between start and offset in enc units.
+func (ix *lineIndex) columnOf(start, offset uint32, enc ColumnEncoding) uint32 {
+	if enc == ColumnBytes {
+		return offset - start
+	}
+	var col uint32
+	for text := ix.src[start:offset]; len(text) > 0; {
+		r, size := utf8.DecodeRune(text)
+		text = text[size:]
+		col += runeWidth(r, enc)
+	}
+	return col
+}
+
+// offsetOf returns the offset col enc units after start, clamped to end.
+func (ix *lineIndex) offsetOf(start, end, col uint32, enc ColumnEncoding) uint32 {
+	if enc == ColumnBytes {
+		return ix.runeStart(min(start+col, end), start)
+	}
+	offset := start
+	for offset < end {
+		r, size := utf8.DecodeRune(ix.src[offset:end])
+		w := runeWidth(r, enc)
+		if col < w {
+			break
+		}
+		col -= w
+		offset += uint32(size)
+	}
+	return offset
+}
+
+func runeWidth(r rune, enc ColumnEncoding) uint32 {
+	if enc == ColumnUTF16 && r > 0xFFFF {
+		return 2
+	}
+	return 1
+}
+
+func (ix *lineIndex) pointForByte(offset uint32, enc ColumnEncoding) Point {
+	offset = min(offset, uint32(len(ix.src)))
+	row := ix.row(offset)
+	start, end := ix.lineBounds(row)
+	offset = ix.runeStart(min(offset, end), start)
+	return Point{Row: uint32(row), Column: ix.columnOf(start, offset, enc)}
+}
+
+func (ix *lineIndex) byteForPoint(p Point, enc ColumnEncoding) uint32 {
+	if int(p.Row) >= len(ix.starts) {
+		return uint32(len(ix.src))
+	}
+	start, end := ix.lineBounds(int(p.Row))
+	return ix.offsetOf(start, end, p.Column, enc)
+}
+
+// PointForByte returns the position of a byte offset in the tree's source,
+// with the column in the tree's ColumnEncoding. An offset inside a
+// multi-byte rune maps to the column where the rune starts, an offset inside
+// a line terminator maps to the end of the line's content, and offsets past
+// the end clamp to the end of the source. Offsets and points are in the
+// whole source, also in a tree parsed with included ranges, so they agree
+// with node positions.
+func (t *Tree) PointForByte(offset uint32) Point {
+	return t.lines().pointForByte(offset, t.columns)
+}
+
+// ByteForPoint returns the byte offset of a position in the tree's source,
+// with the column in the tree's ColumnEncoding. A column past the end of its
+// line clamps to the line terminator, and a row past the last line clamps to
+// the end of the source.
+func (t *Tree) ByteForPoint(p Point) uint32 {
+	return t.lines().byteForPoint(p, t.columns)
+}
+
+// UTF16PointForByte is like PointForByte but always measures the column in
+// UTF-16 code units.
+func (t *Tree) UTF16PointForByte(offset uint32) Point16 {
+	p := t.lines().pointForByte(offset, ColumnUTF16)
+	return Point16{Row: p.Row, Column: p.Column}
+}
+
+// ByteForUTF16Point is the inverse of UTF16PointForByte. A column that falls
+// between the two code units of a surrogate pair maps to the start of the
+// rune.
+func (t *Tree) ByteForUTF16Point(p Point16) uint32 {
+	return t.lines().byteForPoint(Point{Row: p.Row, Column: p.Column}, ColumnUTF16)
+}
+
+// ColumnEncoding returns the unit the tree reports Point columns in.
+func (t *Tree) ColumnEncoding() ColumnEncoding {
+	return t.columns
+}
+
+// encodePoint converts a byte-column point at offset to the tree's encoding.
+func (t *Tree) encodePoint(p sitter.Point, offset uint32) Point {
+	if t.columns == ColumnBytes {
+		return pointFromSitter(p)
+	}
+	ix := t.lines()
+	if int(p.Row) >= len(ix.starts) || offset > uint32(len(ix.src)) {
+		return pointFromSitter(p)
+	}
+	return Point{Row: p.Row, Column: ix.columnOf(ix.starts[p.Row], offset, t.columns)}
+}
@@ -0,0 +1,122 @@
+package grove_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestPointForByte(t *testing.T) {
+	src := "var a = 1;\r\nvar é = \"ü\";\nx;"
+	tree := parse(t, src)
+	tests := []struct {
+		offset uint32
+		want   grove.Point
+	}{
+		{0, grove.Point{Row: 0, Column: 0}},
+		{10, grove.Point{Row: 0, Column: 10}}, // "\r"
+		{11, grove.Point{Row: 0, Column: 10}}, // "\n" of CRLF
+		{12, grove.Point{Row: 1, Column: 0}},
+		{16, grove.Point{Row: 1, Column: 4}}, // first byte of "é"
+		{17, grove.Point{Row: 1, Column: 4}}, // inside "é"
+		{18, grove.Point{Row: 1, Column: 6}},
+		{23, grove.Point{Row: 1, Column: 10}}, // inside "ü"
+		{27, grove.Point{Row: 2, Column: 0}},
+		{29, grove.Point{Row: 2, Column: 2}}, // end of source
+		{99, grove.Point{Row: 2, Column: 2}},
+	}
+	for _, tt := range tests {
+		if got := tree.PointForByte(tt.offset); got != tt.want {
+			t.Errorf("PointForByte(%d) = %+v, want %+v", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestByteForPoint(t *testing.T) {
+	src := "var a = 1;\r\nvar é = \"ü\";\nx;"
+	tree := parse(t, src)
+	tests := []struct {
+		point grove.Point
+		want  uint32
+	}{
+		{grove.Point{Row: 0, Column: 4}, 4},
+		{grove.Point{Row: 0, Column: 50}, 10}, // clamps before "\r\n"
+		{grove.Point{Row: 1, Column: 0}, 12},
+		{grove.Point{Row: 1, Column: 5}, 16}, // inside "é"
+		{grove.Point{Row: 1, Column: 50}, 26},
+		{grove.Point{Row: 2, Column: 1}, 28},
+		{grove.Point{Row: 7, Column: 0}, 29},
+	}
+	for _, tt := range tests {
+		if got := tree.ByteForPoint(tt.point); got != tt.want {
+			t.Errorf("ByteForPoint(%+v) = %d, want %d", tt.point, got, tt.want)
+		}
+	}
+
+	// Node positions reported by tree-sitter round-trip.
+	decl := tree.RootNode().NamedChild(1)
+	if got := tree.ByteForPoint(decl.StartPoint()); got != decl.StartByte() {
+		t.Errorf("ByteForPoint(StartPoint) = %d, want %d", got, decl.StartByte())
+	}
+	if got := tree.PointForByte(decl.EndByte()); got != decl.EndPoint() {
+		t.Errorf("PointForByte(EndByte) = %+v, want %+v", got, decl.EndPoint())
+	}
+}
+
+func TestUTF16Points(t *testing.T) {
+	// "😀" is one rune of four bytes and two UTF-16 units; "é" is "e"
+	// followed by U+0301, two runes of three bytes and two UTF-16 units.
+	src := "var s = \"😀é\"; var n = 1;"
+	tree := parse(t, src)
+	tests := []struct {
+		offset uint32
+		want   uint32
+	}{
+		{9, 9},   // start of the emoji
+		{11, 9},  // inside the emoji
+		{13, 11}, // "e"
+		{14, 12}, // combining acute accent
+		{16, 13}, // closing quote
+		{uint32(len(src)), 26},
+	}
+	for _, tt := range tests {
+		got := tree.UTF16PointForByte(tt.offset)
+		if got != (grove.Point16{Row: 0, Column: tt.want}) {
+			t.Errorf("UTF16PointForByte(%d) = %+v, want column %d", tt.offset, got, tt.want)
+		}
+		if back := tree.ByteForUTF16Point(got); back != tree.ByteForPoint(tree.PointForByte(tt.offset)) {
+			t.Errorf("ByteForUTF16Point(%+v) = %d", got, back)
+		}
+	}
+	// Column 10 falls between the emoji's surrogates.
+	if got := tree.ByteForUTF16Point(grove.Point16{Row: 0, Column: 10}); got != 9 {
+		t.Errorf("ByteForUTF16Point inside surrogate pair = %d, want 9", got)
+	}
+}
+
+func TestColumnEncodingOption(t *testing.T) {
+	src := []byte("var s = \"😀é\"; var n = 1;")
+	for _, tt := range []struct {
+		enc  grove.ColumnEncoding
+		want uint32
+	}{
+		{grove.ColumnBytes, 19},
+		{grove.ColumnUTF16, 16},
+		{grove.ColumnRunes, 15},
+	} {
+		p := newParser(t, ghostlang(t), grove.WithColumnEncoding(tt.enc))
+		tree, err := p.Parse(context.Background(), src)
+		p.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		second := tree.RootNode().NamedChild(1)
+		if got := second.StartPoint().Column; got != tt.want {
+			t.Errorf("encoding %d: second statement starts at column %d, want %d", tt.enc, got, tt.want)
+		}
+		if got := tree.ByteForPoint(second.StartPoint()); got != second.StartByte() {
+			t.Errorf("encoding %d: ByteForPoint(StartPoint) = %d, want %d", tt.enc, got, second.StartByte())
+		}
+	}
+}
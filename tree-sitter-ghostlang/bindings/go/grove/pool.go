@@ -0,0 +1,107 @@
+package grove
+
+import (
+	"context"
+	"sync"
+)
+
+// ParserPool shares parsers for one language between goroutines. A bounded
+// pool never has more than its size of parsers checked out at once.
+type ParserPool struct {
+	lang *Language
+	sem  chan struct{} // nil when the pool is unbounded
+
+	mu         sync.Mutex
+	idle       []*Parser
+	checkedOut map[*Parser]bool
+	closed     bool
+}
+
+// NewParserPool returns a pool of parsers for lang. If size is positive, at
+// most size parsers exist at a time and Get blocks while all are in use;
+// otherwise the pool grows on demand.
+func NewParserPool(lang *Language, size int) *ParserPool {
+	pool := &ParserPool{lang: lang, checkedOut: make(map[*Parser]bool)}
+	if size > 0 {
+		pool.sem = make(chan struct{}, size)
+	}
+	return pool
+}
+
+// Get returns an idle parser, creating one if needed. When the pool is
+// bounded and exhausted, Get waits until a parser is returned with Put or ctx
+// is done, in which case it returns the context's error. Get on a closed
+// pool returns ErrClosed.
+func (pool *ParserPool) Get(ctx context.Context) (*Parser, error) {
+	if pool.sem != nil {
+		select {
+		case pool.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if pool.closed {
+		pool.release()
+		return nil, ErrClosed
+	}
+	if n := len(pool.idle); n > 0 {
+		p := pool.idle[n-1]
+		pool.idle = pool.idle[:n-1]
+		pool.checkedOut[p] = true
+		return p, nil
+	}
+	p, err := NewParser(pool.lang)
+	if err != nil {
+		pool.release()
+		return nil, err
+	}
+	pool.checkedOut[p] = true
+	return p, nil
+}
+
+// release frees a slot taken by Get.
+func (pool *ParserPool) release() {
+	if pool.sem != nil {
+		<-pool.sem
+	}
+}
+
+// Put returns a parser obtained from Get to the pool. The parser is Reset and
+// its settings are restored to their defaults so nothing carries over to the
+// next caller. A parser the caller has closed is dropped. Put panics if p is
+// not checked out of this pool, such as when it is returned twice.
+func (pool *ParserPool) Put(p *Parser) {
+	pool.mu.Lock()
+	if !pool.checkedOut[p] {
+		pool.mu.Unlock()
+		panic("grove: Put of a parser not checked out of this pool")
+	}
+	delete(pool.checkedOut, p)
+	switch {
+	case p.closed:
+		// Dropped; its slot is still released below.
+	case pool.closed:
+		p.Close()
+	default:
+		p.Reset()
+		p.resetSettings()
+		pool.idle = append(pool.idle, p)
+	}
+	pool.mu.Unlock()
+	pool.release()
+}
+
+// Close closes the idle parsers. Parsers still checked out are closed when
+// they are returned.
+func (pool *ParserPool) Close() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	for _, p := range pool.idle {
+		p.Close()
+	}
+	pool.idle = nil
+	pool.closed = true
+}
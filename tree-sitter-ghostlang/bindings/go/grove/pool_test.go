@@ -0,0 +1,200 @@
+package grove_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestParserPoolBounded(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	defer pool.Close()
+
+	p, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get on exhausted pool = %v, want DeadlineExceeded", err)
+	}
+
+	pool.Put(p)
+	again, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != p {
+		t.Errorf("pool did not reuse the returned parser")
+	}
+	pool.Put(again)
+}
+
+func TestParserPoolConcurrent(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 2)
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := pool.Get(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer pool.Put(p)
+			tree, err := p.Parse(context.Background(), []byte("var x = 1;"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			tree.Close()
+		}()
+	}
+	wg.Wait()
+}
+
+var benchSource = []byte("function f(a, b) {\n    var c = a + b * 2;\n    if (c > 10) { return c; }\n    return notify(\"small\");\n}\n")
+
+func BenchmarkParsePooled(b *testing.B) {
+	pool := grove.NewParserPool(ghostlang(b), 1)
+	defer pool.Close()
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p, err := pool.Get(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tree, err := p.Parse(ctx, benchSource)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tree.Close()
+		pool.Put(p)
+	}
+}
+
+func BenchmarkParseFresh(b *testing.B) {
+	lang := ghostlang(b)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p := newParser(b, lang)
+		tree, err := p.Parse(ctx, benchSource)
+		if err != nil {
+			b.Fatal(err)
+		}
+		tree.Close()
+		p.Close()
+	}
+}
+
+func TestParserPoolPutResets(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	defer pool.Close()
+
+	p, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := p.Parse(ctx, largeSource(20000)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Parse = %v, want context.DeadlineExceeded", err)
+	}
+	pool.Put(p)
+
+	p, err = pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Put(p)
+	tree, err := p.Parse(context.Background(), []byte("var x = 5;"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+	if tree.HasError() || tree.RootNode().EndByte() != 10 {
+		t.Errorf("parse after Put produced %s", tree)
+	}
+}
+
+func TestParserPoolGetFailureReleasesSlot(t *testing.T) {
+	// A TSLanguage starts with its ABI version. Changing it after NewLanguage
+	// makes NewParser reject the language.
+	fake := make([]uint32, 64)
+	fake[0] = grove.LanguageVersion
+	lang, err := grove.NewLanguage("fake", unsafe.Pointer(&fake[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake[0] = grove.LanguageVersion + 1
+	pool := grove.NewParserPool(lang, 1)
+	defer pool.Close()
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := pool.Get(ctx)
+		cancel()
+		if !errors.Is(err, grove.ErrIncompatibleLanguage) {
+			t.Fatalf("Get %d = %v, want ErrIncompatibleLanguage", i, err)
+		}
+	}
+}
+
+func TestParserPoolGetAfterClose(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	pool.Close()
+	if p, err := pool.Get(context.Background()); p != nil || !errors.Is(err, grove.ErrClosed) {
+		t.Fatalf("Get on a closed pool = %v, %v; want ErrClosed", p, err)
+	}
+	// The failed Get gave its slot back.
+	if _, err := pool.Get(context.Background()); !errors.Is(err, grove.ErrClosed) {
+		t.Fatalf("second Get on a closed pool = %v, want ErrClosed", err)
+	}
+}
+
+func TestParserPoolPutMisuse(t *testing.T) {
+	pool := grove.NewParserPool(ghostlang(t), 1)
+	defer pool.Close()
+	putPanics := func(p *grove.Parser) (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		pool.Put(p)
+		return false
+	}
+
+	p, err := pool.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Put(p)
+	if !putPanics(p) {
+		t.Errorf("second Put of a parser did not panic")
+	}
+	foreign := newParser(t, ghostlang(t))
+	if !putPanics(foreign) {
+		t.Errorf("Put of a parser from elsewhere did not panic")
+	}
+
+	// Neither misuse took the slot or duplicated the idle parser.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	again, err := pool.Get(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != p {
+		t.Errorf("pool did not reuse the returned parser")
+	}
+	pool.Put(again)
+}
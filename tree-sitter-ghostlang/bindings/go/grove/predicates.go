@@ -0,0 +1,206 @@
+package grove
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// predicateArg is a capture reference or a string literal in a predicate.
+type predicateArg struct {
+	capture int // capture index, or -1 for a string literal
+	value   string
+}
+
+func (a predicateArg) isCapture() bool {
+	return a.capture >= 0
+}
+
+// predicate is a parsed #op? filter or #op! directive from a query pattern.
+type predicate struct {
+	op   string
+	args []predicateArg
+	re   *regexp.Regexp // compiled pattern for #match? and #not-match?
+}
+
+// filterOps are the predicates that decide whether a match is yielded.
+var filterOps = map[string]bool{
+	"eq?":         true,
+	"not-eq?":     true,
+	"match?":      true,
+	"not-match?":  true,
+	"any-of?":     true,
+	"not-any-of?": true,
+}
+
+// directiveOps attach metadata to a pattern without filtering its matches.
+var directiveOps = map[string]bool{
+	"set!":             true,
+	"select-adjacent!": true,
+}
+
+// unsupportedOps are predicates of other tree-sitter hosts that grove does
+// not evaluate. They are rejected rather than ignored, so that a query does
+// not silently match more than its author intended.
+var unsupportedOps = map[string]bool{
+	"is?":         true,
+	"is-not?":     true,
+	"make-range!": true,
+}
+
+// parsePredicates reads and validates the predicates of every pattern in q,
+// splitting them into filters and directives.
+func (q *Query) parsePredicates() error {
+	n := q.ts.PatternCount()
+	q.filters = make([][]predicate, n)
+	q.directives = make([][]predicate, n)
+	for i := uint32(0); i < n; i++ {
+		for _, steps := range q.ts.PredicatesForPattern(i) {
+			if len(steps) == 0 {
+				continue
+			}
+			p := predicate{op: q.ts.StringValueForId(steps[0].ValueId)}
+			for _, s := range steps[1:] {
+				switch s.Type {
+				case sitter.QueryPredicateStepTypeCapture:
+					p.args = append(p.args, predicateArg{capture: int(s.ValueId)})
+				case sitter.QueryPredicateStepTypeString:
+					p.args = append(p.args, predicateArg{capture: -1, value: q.ts.StringValueForId(s.ValueId)})
+				}
+			}
+			if err := q.checkPredicate(i, &p); err != nil {
+				return err
+			}
+			if filterOps[p.op] {
+				q.filters[i] = append(q.filters[i], p)
+			} else {
+				q.directives[i] = append(q.directives[i], p)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPredicate validates p, a predicate of the given pattern. Its errors
+// point at the predicate within the pattern's source.
+func (q *Query) checkPredicate(pattern uint32, p *predicate) error {
+	fail := func(format string, args ...any) error {
+		offset := tsQueryStartByteForPattern(q.ts, pattern)
+		if i := strings.Index(q.source[offset:], "#"+p.op); i >= 0 {
+			offset += uint32(i)
+		}
+		return &QueryError{
+			Offset:  offset,
+			Kind:    "predicate",
+			Message: fmt.Sprintf("#%s: ", p.op) + fmt.Sprintf(format, args...),
+		}
+	}
+	switch {
+	case unsupportedOps[p.op]:
+		return fail("unsupported predicate")
+	case !filterOps[p.op] && !directiveOps[p.op]:
+		return fail("unknown predicate")
+	}
+	switch p.op {
+	case "eq?", "not-eq?":
+		if len(p.args) != 2 || !p.args[0].isCapture() {
+			return fail("want a capture and a capture or string")
+		}
+	case "match?", "not-match?":
+		if len(p.args) != 2 || !p.args[0].isCapture() || p.args[1].isCapture() {
+			return fail("want a capture and a regular expression")
+		}
+		re, err := regexp.Compile(p.args[1].value)
+		if err != nil {
+			return fail("%v", err)
+		}
+		p.re = re
+	case "any-of?", "not-any-of?":
+		if len(p.args) < 2 || !p.args[0].isCapture() {
+			return fail("want a capture and one or more strings")
+		}
+	case "set!":
+		if len(p.args) < 1 || len(p.args) > 2 || p.args[0].isCapture() {
+			return fail("want a key and an optional value")
+		}
+	case "select-adjacent!":
+		if len(p.args) != 2 || !p.args[0].isCapture() || !p.args[1].isCapture() {
+			return fail("want two captures")
+		}
+	}
+	return nil
+}
+
+// satisfies reports whether the captures of a match for pattern pass all of
+// its filter predicates. Captures absent from the match pass vacuously.
+func (q *Query) satisfies(pattern int, captures []QueryCapture) bool {
+	for i := range q.filters[pattern] {
+		if !q.filters[pattern][i].eval(captures) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *predicate) eval(captures []QueryCapture) bool {
+	target := p.args[0].capture
+	for _, c := range captures {
+		if int(c.Index) != target {
+			continue
+		}
+		text := c.Node.Text()
+		var ok bool
+		switch p.op {
+		case "eq?", "not-eq?":
+			other := p.args[1]
+			if other.isCapture() {
+				n := captureNode(captures, other.capture)
+				if n == nil {
+					continue
+				}
+				ok = bytes.Equal(text, n.Text())
+			} else {
+				ok = string(text) == other.value
+			}
+		case "match?", "not-match?":
+			ok = p.re.Match(text)
+		case "any-of?", "not-any-of?":
+			for _, a := range p.args[1:] {
+				if string(text) == a.value {
+					ok = true
+					break
+				}
+			}
+		}
+		if strings.HasPrefix(p.op, "not-") == ok {
+			return false
+		}
+	}
+	return true
+}
+
+func captureNode(captures []QueryCapture, index int) *Node {
+	for _, c := range captures {
+		if int(c.Index) == index {
+			return c.Node
+		}
+	}
+	return nil
+}
+
+// property returns the value that a #set! directive of pattern gives key. A
+// one-argument #set! yields an empty value.
+func (q *Query) property(pattern int, key string) (string, bool) {
+	for _, d := range q.directives[pattern] {
+		if d.op == "set!" && d.args[0].value == key {
+			if len(d.args) == 2 {
+				return d.args[1].value, true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,373 @@
+package grove
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// QueryError reports a query that failed to compile.
+type QueryError struct {
+	// Offset is the byte offset into the query source where the error was
+	// detected.
+	Offset uint32
+	// Kind names the failing construct: "syntax", "node type", "field",
+	// "capture", "structure", "language" or "predicate".
+	Kind    string
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query: %s (offset %d)", e.Message, e.Offset)
+}
+
+var queryErrorKinds = map[sitter.QueryErrorType]string{
+	sitter.QueryErrorSyntax:    "syntax",
+	sitter.QueryErrorNodeType:  "node type",
+	sitter.QueryErrorField:     "field",
+	sitter.QueryErrorCapture:   "capture",
+	sitter.QueryErrorStructure: "structure",
+	sitter.QueryErrorLanguage:  "language",
+}
+
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]*`)
+
+func newQueryError(source string, offset uint32, kind sitter.QueryErrorType) *QueryError {
+	prefix := source[:offset]
+	line := strings.Count(prefix, "\n") + 1
+	column := len(prefix) - (strings.LastIndex(prefix, "\n") + 1) + 1
+	e := &QueryError{Offset: offset, Kind: queryErrorKinds[kind]}
+	switch kind {
+	case sitter.QueryErrorNodeType, sitter.QueryErrorField, sitter.QueryErrorCapture:
+		if name := identifierPattern.FindString(source[offset:]); name != "" {
+			e.Message = fmt.Sprintf("invalid %s %q at line %d, column %d", e.Kind, name, line, column)
+			return e
+		}
+	}
+	e.Message = fmt.Sprintf("invalid %s at line %d, column %d", e.Kind, line, column)
+	return e
+}
+
+// Query is a compiled tree-sitter query. A Query is immutable and safe for
+// concurrent use; each call to Matches gets its own cursor.
+type Query struct {
+	*compiledQuery
+	closed atomic.Bool
+}
+
+// compiledQuery is the compiled form of a query, shared by the Query handles
+// NewQuery returns for its source.
+type compiledQuery struct {
+	lang         *Language
+	source       string
+	ts           *sitter.Query
+	captureNames []string
+	filters      [][]predicate // per pattern, evaluated before a match is yielded
+	directives   [][]predicate // per pattern, #set! and friends
+	refs         int           // open handles, plus one while cached; guarded by queries.mu
+}
+
+// NewQuery compiles the S-expression query source for lang. Compiled queries
+// are cached, so compiling the same source again is cheap and shares the
+// compiled form. A *QueryError describes why compilation failed. The
+// supported predicates are #eq?, #match? and #any-of?, their #not-
+// negations, #set! and #select-adjacent!; others are rejected.
+func NewQuery(lang *Language, source string) (*Query, error) {
+	key := queryKey{lang: lang.id(), source: source}
+	c, ok := queries.acquire(key)
+	if !ok {
+		compiled, err := compileQuery(lang, source)
+		if err != nil {
+			return nil, err
+		}
+		c = queries.add(key, compiled)
+	}
+	q := &Query{compiledQuery: c}
+	runtime.SetFinalizer(q, (*Query).Close)
+	return q, nil
+}
+
+func compileQuery(lang *Language, source string) (*compiledQuery, error) {
+	ts, offset, kind := tsQueryNew(lang, source)
+	if ts == nil {
+		return nil, newQueryError(source, offset, kind)
+	}
+
+	c := &compiledQuery{lang: lang, source: source, ts: ts}
+	c.captureNames = make([]string, ts.CaptureCount())
+	for i := range c.captureNames {
+		c.captureNames[i] = ts.CaptureNameForId(uint32(i))
+	}
+	if err := (&Query{compiledQuery: c}).parsePredicates(); err != nil {
+		ts.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close releases the query, after which running it panics with ErrClosed.
+// Other queries NewQuery returned for the same source are unaffected: the
+// compiled query is freed once every one of them is closed and the cache has
+// evicted it. Calling Close again does nothing. A query that is never closed
+// is released when it is garbage collected.
+func (q *Query) Close() {
+	if q.closed.Swap(true) {
+		return
+	}
+	queries.release(q.compiledQuery)
+}
+
+// live returns the tree-sitter query, panicking if q is closed.
+func (q *Query) live() *sitter.Query {
+	if q.closed.Load() {
+		panic(ErrClosed)
+	}
+	return q.ts
+}
+
+// Language returns the language the query was compiled for.
+func (q *Query) Language() *Language {
+	return q.lang
+}
+
+// Source returns the query source text.
+func (q *Query) Source() string {
+	return q.source
+}
+
+// PatternCount returns the number of patterns in the query.
+func (q *Query) PatternCount() int {
+	return int(q.live().PatternCount())
+}
+
+// CaptureNames returns the capture names used by the query, indexed by
+// capture index.
+func (q *Query) CaptureNames() []string {
+	return q.captureNames
+}
+
+// CaptureIndexForName returns the index of the capture called name, as found
+// in QueryCapture.Index, so that loops can compare integers rather than
+// names.
+func (q *Query) CaptureIndexForName(name string) (uint32, bool) {
+	for i, n := range q.captureNames {
+		if n == name {
+			return uint32(i), true
+		}
+	}
+	return 0, false
+}
+
+// QueryCapture is a node captured by a query.
+type QueryCapture struct {
+	Index uint32
+	Name  string
+	Node  *Node
+}
+
+// QueryMatch is one match of a query pattern.
+type QueryMatch struct {
+	PatternIndex int
+	Captures     []QueryCapture
+}
+
+// Capture returns the first node captured under name, or nil.
+func (m QueryMatch) Capture(name string) *Node {
+	for _, c := range m.Captures {
+		if c.Name == name {
+			return c.Node
+		}
+	}
+	return nil
+}
+
+// QueryMatches iterates over the matches of a query.
+type QueryMatches struct {
+	q        *Query
+	tree     *Tree
+	cursor   *sitter.QueryCursor
+	exceeded bool
+}
+
+// Matches runs the query on node and its descendants.
+func (q *Query) Matches(node *Node) *QueryMatches {
+	cursor := sitter.NewQueryCursor()
+	cursor.Exec(q.live(), node.live())
+	return &QueryMatches{q: q, tree: node.tree, cursor: cursor}
+}
+
+// MatchesInRange runs the query on node and its descendants, yielding only
+// matches with a captured node that intersects the byte range [start, end).
+// A match that straddles either bound is still returned whole. Scoping a
+// query to the visible part of a large file avoids visiting the rest of
+// the tree.
+func (q *Query) MatchesInRange(node *Node, start, end uint32) *QueryMatches {
+	cursor := sitter.NewQueryCursor()
+	tsQueryCursorSetByteRange(cursor, start, end)
+	cursor.Exec(q.live(), node.live())
+	return &QueryMatches{q: q, tree: node.tree, cursor: cursor}
+}
+
+// SetMatchLimit caps the number of matches tree-sitter keeps in progress at
+// once. When a pattern would exceed it, the oldest in-progress matches are
+// dropped and DidExceedMatchLimit reports true, so the results may be
+// incomplete. Call it before the first Next; zero restores the default of
+// no limit.
+func (m *QueryMatches) SetMatchLimit(n uint32) {
+	if m.cursor == nil {
+		return
+	}
+	if n == 0 {
+		n = math.MaxUint32
+	}
+	tsQueryCursorSetMatchLimit(m.cursor, n)
+}
+
+// DidExceedMatchLimit reports whether matches were dropped because of the
+// limit set with SetMatchLimit. It stays valid after the iterator is closed.
+func (m *QueryMatches) DidExceedMatchLimit() bool {
+	if m.cursor != nil {
+		return tsQueryCursorDidExceedMatchLimit(m.cursor)
+	}
+	return m.exceeded
+}
+
+// Next returns the next match whose captures satisfy the pattern's #eq?,
+// #not-eq?, #match?, #not-match?, #any-of? and #not-any-of? predicates. It
+// returns false once the matches are exhausted, after which the iterator
+// holds no resources.
+func (m *QueryMatches) Next() (QueryMatch, bool) {
+	for m.cursor != nil {
+		m.q.live()
+		tm, ok := m.cursor.NextMatch()
+		if !ok {
+			m.Close()
+			break
+		}
+		match := m.convert(tm)
+		if m.q.satisfies(match.PatternIndex, match.Captures) {
+			return match, true
+		}
+	}
+	return QueryMatch{}, false
+}
+
+// Close releases the iterator's cursor. It is only needed when the
+// iteration is abandoned before Next returns false.
+func (m *QueryMatches) Close() {
+	if m.cursor != nil {
+		m.exceeded = tsQueryCursorDidExceedMatchLimit(m.cursor)
+		m.cursor.Close()
+		m.cursor = nil
+	}
+}
+
+func (m *QueryMatches) convert(tm *sitter.QueryMatch) QueryMatch {
+	return m.convertInto(nil, tm)
+}
+
+// convertInto is like convert but appends the captures to buf[:0].
+func (m *QueryMatches) convertInto(buf []QueryCapture, tm *sitter.QueryMatch) QueryMatch {
+	match := QueryMatch{PatternIndex: int(tm.PatternIndex), Captures: buf[:0]}
+	for _, c := range tm.Captures {
+		match.Captures = append(match.Captures, QueryCapture{
+			Index: c.Index,
+			Name:  m.q.captureNames[c.Index],
+			Node:  m.tree.node(c.Node),
+		})
+	}
+	return match
+}
+
+// Stream runs the query on node and its descendants, calling fn with each
+// match that Next would return, as the cursor reaches it. Returning false
+// from fn stops the query cursor at once, so the rest of the tree is not
+// searched.
+//
+// Stream reuses the match's Captures slice from one call to the next, so
+// keep only copies of it, or of the nodes in it, beyond the call.
+func (q *Query) Stream(node *Node, fn func(QueryMatch) bool) {
+	m := q.Matches(node)
+	defer m.Close()
+	var buf []QueryCapture
+	for {
+		q.live()
+		tm, ok := m.cursor.NextMatch()
+		if !ok {
+			return
+		}
+		match := m.convertInto(buf, tm)
+		buf = match.Captures
+		if q.satisfies(match.PatternIndex, match.Captures) && !fn(match) {
+			return
+		}
+	}
+}
+
+// QueryCaptures iterates over the captures of a query one at a time.
+type QueryCaptures struct {
+	q      *Query
+	tree   *Tree
+	cursor *sitter.QueryCursor
+	buf    []QueryCapture
+}
+
+// Captures runs the query on node and its descendants like Matches, but
+// yields each captured node on its own rather than grouped into matches.
+//
+// Matches returns matches in the order they finish, so the captures of a
+// pattern that spans a whole function arrive after those of the patterns
+// inside it. Captures instead returns captures in document order, by start
+// byte and then outermost first, which is the order a highlighter paints in.
+// Captures of the same node by several patterns arrive in pattern order. As
+// with Matches, a capture is only yielded once the rest of its match is
+// known to satisfy the pattern's predicates.
+func (q *Query) Captures(node *Node) *QueryCaptures {
+	cursor := sitter.NewQueryCursor()
+	cursor.Exec(q.live(), node.live())
+	return &QueryCaptures{q: q, tree: node.tree, cursor: cursor}
+}
+
+// Next returns the index and node of the next capture. It returns false
+// once the captures are exhausted, after which the iterator holds no
+// resources. Only the returned node is allocated; patterns with filter
+// predicates also allocate to evaluate them.
+func (c *QueryCaptures) Next() (uint32, *Node, bool) {
+	for c.cursor != nil {
+		c.q.live()
+		m, ok := tsQueryCursorNextCapture(c.cursor)
+		if !ok {
+			c.Close()
+			break
+		}
+		ts := c.tree.live()
+		if len(c.q.filters[m.pattern]) > 0 {
+			c.buf = c.buf[:0]
+			for i := range m.captures {
+				index := m.index(i)
+				c.buf = append(c.buf, QueryCapture{Index: index, Name: c.q.captureNames[index], Node: c.tree.node(m.node(ts, i))})
+			}
+			if !c.q.satisfies(m.pattern, c.buf) {
+				// Drop the match so its other captures are not checked again.
+				tsQueryCursorRemoveMatch(c.cursor, m.id)
+				continue
+			}
+		}
+		return m.index(m.current), c.tree.node(m.node(ts, m.current)), true
+	}
+	return 0, nil, false
+}
+
+// Close releases the iterator's cursor. It is only needed when the
+// iteration is abandoned before Next returns false.
+func (c *QueryCaptures) Close() {
+	if c.cursor != nil {
+		c.cursor.Close()
+		c.cursor = nil
+	}
+}
@@ -0,0 +1,116 @@
+package grove
+
+import (
+	"container/list"
+	"sync"
+)
+
+// queryCacheSize bounds the number of compiled queries kept by NewQuery.
+const queryCacheSize = 128
+
+type queryKey struct {
+	lang   uint64
+	source string
+}
+
+type queryEntry struct {
+	key   queryKey
+	query *compiledQuery
+}
+
+// queryCache is a least-recently-used cache of compiled queries. It also
+// counts the references to the queries it hands out, cached or not, and
+// frees a compiled query when the last one goes.
+type queryCache struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // front is most recently used
+	items map[queryKey]*list.Element
+}
+
+var queries = newQueryCache(queryCacheSize)
+
+func newQueryCache(max int) *queryCache {
+	return &queryCache{max: max, order: list.New(), items: make(map[queryKey]*list.Element)}
+}
+
+// acquire returns the cached query for key with a reference taken for the
+// caller.
+func (c *queryCache) acquire(key queryKey) (*compiledQuery, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	q := el.Value.(*queryEntry).query
+	q.refs++
+	return q, true
+}
+
+// add caches q for key and returns it with a reference taken for the caller.
+// If another caller cached key first, add frees q and returns that query
+// instead.
+func (c *queryCache) add(key queryKey, q *compiledQuery) *compiledQuery {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		q.ts.Close()
+		q = el.Value.(*queryEntry).query
+		q.refs++
+		return q
+	}
+	q.refs = 2
+	c.items[key] = c.order.PushFront(&queryEntry{key: key, query: q})
+	for c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*queryEntry).key)
+		c.unref(oldest.Value.(*queryEntry).query)
+	}
+	return q
+}
+
+// release drops a reference taken by acquire or add.
+func (c *queryCache) release(q *compiledQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unref(q)
+}
+
+func (c *queryCache) unref(q *compiledQuery) {
+	if q.refs--; q.refs == 0 {
+		q.ts.Close()
+	}
+}
+
+// evict drops the cached queries of the language with the given id.
+func (c *queryCache) evict(lang uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, el := range c.items {
+		if key.lang == lang {
+			c.order.Remove(el)
+			delete(c.items, key)
+			c.unref(el.Value.(*queryEntry).query)
+		}
+	}
+}
+
+func (c *queryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, el := range c.items {
+		c.unref(el.Value.(*queryEntry).query)
+	}
+	c.order.Init()
+	c.items = make(map[queryKey]*list.Element)
+}
+
+// ClearQueryCache drops every compiled query cached by NewQuery. Queries
+// already returned by NewQuery keep working.
+func ClearQueryCache() {
+	queries.clear()
+}
@@ -0,0 +1,363 @@
+package grove_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestQueryMatches(t *testing.T) {
+	tree := parse(t, "function a() {}\nvar x = 1;\nfunction b(y) {}\n")
+	q, err := grove.NewQuery(ghostlang(t), "(function_declaration name: (identifier) @name) @fn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	matches := q.Matches(tree.RootNode())
+	for {
+		m, ok := matches.Next()
+		if !ok {
+			break
+		}
+		if m.Capture("fn").Kind() != "function_declaration" {
+			t.Errorf("@fn captured %q", m.Capture("fn").Kind())
+		}
+		names = append(names, m.Capture("name").Utf8Text())
+	}
+	if len(names) != 2 || names[0] != "a" || names[1] != "b" {
+		t.Errorf("matched names %v, want [a b]", names)
+	}
+}
+
+func TestQueryCache(t *testing.T) {
+	grove.ClearQueryCache()
+	src := "(identifier) @id"
+	compile := func() {
+		q, err := grove.NewQuery(ghostlang(t), src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.Close()
+	}
+	compile()
+	// A cache hit only allocates the returned handle; compiling allocates
+	// the capture names and predicate tables as well.
+	if allocs := testing.AllocsPerRun(10, compile); allocs > 2 {
+		t.Errorf("NewQuery of a cached source allocated %v times", allocs)
+	}
+	grove.ClearQueryCache()
+	if allocs := testing.AllocsPerRun(1, func() { compile(); grove.ClearQueryCache() }); allocs <= 2 {
+		t.Errorf("NewQuery after ClearQueryCache allocated %v times; the query was not recompiled", allocs)
+	}
+}
+
+func TestQueryCloseSharedSource(t *testing.T) {
+	tree := parse(t, "var x = 1;\n")
+	defer tree.Close()
+	src := "(variable_declaration name: (identifier) @name)"
+	q1, err := grove.NewQuery(ghostlang(t), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q2, err := grove.NewQuery(ghostlang(t), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q1.Close()
+
+	if got := matchCount(q2, tree); got != 1 {
+		t.Errorf("query sharing a closed query's source matched %d times, want 1", got)
+	}
+	// Evicting the compiled query leaves it to q2.
+	grove.ClearQueryCache()
+	if got := matchCount(q2, tree); got != 1 {
+		t.Errorf("query matched %d times after ClearQueryCache, want 1", got)
+	}
+	q2.Close()
+
+	q3, err := grove.NewQuery(ghostlang(t), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q3.Close()
+	if got := matchCount(q3, tree); got != 1 {
+		t.Errorf("recompiled query matched %d times, want 1", got)
+	}
+}
+
+func matchCount(q *grove.Query, tree *grove.Tree) int {
+	n := 0
+	matches := q.Matches(tree.RootNode())
+	for _, ok := matches.Next(); ok; _, ok = matches.Next() {
+		n++
+	}
+	return n
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		kind   string
+		offset uint32
+	}{
+		{"(function_declaration (nope))", "node type", 23},
+		{"(function_declaration nope: (identifier))", "field", 22},
+		{"((identifier) @id (#eq? @missing \"x\"))", "capture", 25},
+		{"(identifier", "syntax", 11},
+	}
+	for _, tt := range tests {
+		_, err := grove.NewQuery(ghostlang(t), tt.source)
+		var qe *grove.QueryError
+		if !errors.As(err, &qe) {
+			t.Errorf("NewQuery(%q) = %v, want *QueryError", tt.source, err)
+			continue
+		}
+		if qe.Kind != tt.kind || qe.Offset != tt.offset {
+			t.Errorf("NewQuery(%q) error = %s at %d, want %s at %d", tt.source, qe.Kind, qe.Offset, tt.kind, tt.offset)
+		}
+	}
+}
+
+func matchTexts(t *testing.T, tree *grove.Tree, source, capture string) []string {
+	t.Helper()
+	q, err := grove.NewQuery(ghostlang(t), source)
+	if err != nil {
+		t.Fatalf("NewQuery(%q): %v", source, err)
+	}
+	var texts []string
+	matches := q.Matches(tree.RootNode())
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		texts = append(texts, m.Capture(capture).Utf8Text())
+	}
+	return texts
+}
+
+func TestQueryPredicates(t *testing.T) {
+	tree := parse(t, "var _a = 1;\nvar b = 2;\nvar c = c;\n")
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{`((identifier) @n (#match? @n "^_"))`, "_a"},
+		{`((identifier) @n (#not-match? @n "^[_c]"))`, "b"},
+		{`((identifier) @n (#eq? @n "b"))`, "b"},
+		{`((variable_declaration name: (identifier) @n) (#not-eq? @n "_a") (#not-eq? @n "c"))`, "b"},
+		{`(variable_declaration name: (identifier) @n value: (_) @v (#eq? @n @v))`, "c"},
+		{`((identifier) @n (#any-of? @n "x" "b"))`, "b"},
+	}
+	for _, tt := range tests {
+		got := matchTexts(t, tree, tt.source, "n")
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%s matched %v, want [%s]", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestQueryPredicateErrors(t *testing.T) {
+	for _, source := range []string{
+		`((identifier) @n (#frobnicate? @n "x"))`,
+		`((identifier) @n (#match? @n "("))`,
+		`((identifier) @n (#match? @n @n))`,
+		`((identifier) @n (#is? local))`,
+		`((identifier) @n (#is-not? local))`,
+		`((block_statement "{" @a "}" @b) (#make-range! "inner" @a @b))`,
+	} {
+		_, err := grove.NewQuery(ghostlang(t), source)
+		var qe *grove.QueryError
+		if !errors.As(err, &qe) || qe.Kind != "predicate" {
+			t.Errorf("NewQuery(%q) = %v, want predicate error", source, err)
+		}
+	}
+}
+
+func TestQueryPredicateErrorOffset(t *testing.T) {
+	// The failing predicate's op also appears in an earlier pattern.
+	source := "((identifier) @a (#eq? @a \"x\"))\n((identifier) @b (#eq? \"x\" @b))\n"
+	_, err := grove.NewQuery(ghostlang(t), source)
+	var qe *grove.QueryError
+	if !errors.As(err, &qe) {
+		t.Fatalf("NewQuery = %v, want a QueryError", err)
+	}
+	if want := uint32(strings.LastIndex(source, "#eq?")); qe.Offset != want {
+		t.Errorf("Offset = %d, want %d", qe.Offset, want)
+	}
+}
+
+func TestQueryOneArgumentDirective(t *testing.T) {
+	source := `((object_literal) @injection.content
+ (#set! injection.language "json")
+ (#set! injection.include-children))`
+	if _, err := grove.NewQuery(ghostlang(t), source); err != nil {
+		t.Errorf("NewQuery: %v", err)
+	}
+}
+
+func TestQueryMatchesInRange(t *testing.T) {
+	src := "function a() { return 1; }\nfunction b() {\n  var x = 1;\n  return x;\n}\nfunction c() { return 3; }\n"
+	tree := parse(t, src)
+	defer tree.Close()
+	q, err := grove.NewQuery(ghostlang(t), "(function_declaration name: (identifier) @name) @fn")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The range covers only part of b's body: a and c lie entirely outside
+	// it, while b's match straddles it and comes back with all captures.
+	start := uint32(strings.Index(src, "var x"))
+	matches := q.MatchesInRange(tree.RootNode(), start, start+uint32(len("var x")))
+	var names []string
+	for {
+		m, ok := matches.Next()
+		if !ok {
+			break
+		}
+		if len(m.Captures) != 2 {
+			t.Errorf("match has %d captures, want 2", len(m.Captures))
+		}
+		names = append(names, m.Capture("name").Utf8Text())
+	}
+	if len(names) != 1 || names[0] != "b" {
+		t.Errorf("matched names %v, want [b]", names)
+	}
+}
+
+func TestQueryMatchLimit(t *testing.T) {
+	tree := parse(t, strings.Repeat("var x = 1;\n", 40))
+	defer tree.Close()
+	// Unanchored sibling captures keep a match in progress per statement.
+	q, err := grove.NewQuery(ghostlang(t), "(source_file (_) @a (_) @b)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := func(limit uint32) (int, bool) {
+		matches := q.Matches(tree.RootNode())
+		matches.SetMatchLimit(limit)
+		n := 0
+		for {
+			if _, ok := matches.Next(); !ok {
+				return n, matches.DidExceedMatchLimit()
+			}
+			n++
+		}
+	}
+
+	all, exceeded := count(0)
+	if exceeded {
+		t.Errorf("unlimited run reported exceeding the match limit")
+	}
+	limited, exceeded := count(4)
+	if !exceeded {
+		t.Errorf("DidExceedMatchLimit = false with a limit of 4")
+	}
+	if limited >= all {
+		t.Errorf("limited run yielded %d matches, unlimited %d", limited, all)
+	}
+}
+
+func TestQueryStream(t *testing.T) {
+	tree := parse(t, "function a() {}\nvar x = 1;\nfunction b(y) {}\nfunction c() {}\n")
+	defer tree.Close()
+	q, err := grove.NewQuery(ghostlang(t), "(function_declaration name: (identifier) @name)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	q.Stream(tree.RootNode(), func(m grove.QueryMatch) bool {
+		names = append(names, m.Capture("name").Utf8Text())
+		return true
+	})
+	if strings.Join(names, " ") != "a b c" {
+		t.Errorf("streamed names %v, want [a b c]", names)
+	}
+
+	calls := 0
+	q.Stream(tree.RootNode(), func(m grove.QueryMatch) bool {
+		calls++
+		return m.Capture("name").Utf8Text() != "b"
+	})
+	if calls != 2 {
+		t.Errorf("Stream called fn %d times after it returned false at the second match", calls)
+	}
+}
+
+func BenchmarkQueryStream(b *testing.B) {
+	tree := parse(b, string(largeSource(2000)))
+	defer tree.Close()
+	q, err := grove.NewQuery(ghostlang(b), "(identifier) @id")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.Stream(tree.RootNode(), func(grove.QueryMatch) bool { return true })
+	}
+}
+
+func TestQueryCaptures(t *testing.T) {
+	src := "function outer() { var a = b; }\nvar c = d;\n"
+	tree := parse(t, src)
+	defer tree.Close()
+	q, err := grove.NewQuery(ghostlang(t), `
+(statement) @stmt
+(function_declaration) @fn
+((identifier) @id (#not-eq? @id "b"))
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt, _ := q.CaptureIndexForName("stmt")
+	fn, _ := q.CaptureIndexForName("fn")
+	id, _ := q.CaptureIndexForName("id")
+	if _, ok := q.CaptureIndexForName("missing"); ok {
+		t.Errorf("CaptureIndexForName found an unknown capture")
+	}
+	names := map[uint32]string{stmt: "stmt", fn: "fn", id: "id"}
+
+	var got []string
+	captures := q.Captures(tree.RootNode())
+	for {
+		index, node, ok := captures.Next()
+		if !ok {
+			break
+		}
+		got = append(got, names[index]+":"+strings.Fields(node.Utf8Text())[0])
+	}
+	// Document order, outer nodes before the nodes they start with, and b
+	// filtered out by its predicate.
+	want := "stmt:function fn:function id:outer stmt:var id:a stmt:var id:c id:d"
+	if strings.Join(got, " ") != want {
+		t.Errorf("captures\n%s\nwant\n%s", strings.Join(got, " "), want)
+	}
+}
+
+func TestQueryCapturesOrderVersusMatches(t *testing.T) {
+	tree := parse(t, "var a = b;\n")
+	defer tree.Close()
+	// The declaration's match only completes at its value, after the match
+	// for the name has been found.
+	q, err := grove.NewQuery(ghostlang(t), "(variable_declaration value: (_)) @decl (identifier) @id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var matches []string
+	m := q.Matches(tree.RootNode())
+	for match, ok := m.Next(); ok; match, ok = m.Next() {
+		matches = append(matches, match.Captures[0].Name)
+	}
+	var captures []string
+	c := q.Captures(tree.RootNode())
+	for index, _, ok := c.Next(); ok; index, _, ok = c.Next() {
+		captures = append(captures, q.CaptureNames()[index])
+	}
+	if got := strings.Join(matches, " "); got != "id decl id" {
+		t.Errorf("Matches order = %s, want id decl id", got)
+	}
+	if got := strings.Join(captures, " "); got != "decl id id" {
+		t.Errorf("Captures order = %s, want decl id id", got)
+	}
+}
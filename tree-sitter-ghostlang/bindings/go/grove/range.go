@@ -0,0 +1,73 @@
+package grove
+
+import (
+	"math"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Range is a span of source text given both as bytes [StartByte, EndByte)
+// and as points. Points use rows and byte columns, whatever ColumnEncoding
+// the tree uses.
+type Range struct {
+	StartByte  uint32
+	EndByte    uint32
+	StartPoint Point
+	EndPoint   Point
+}
+
+// fullRange is the range tree-sitter includes when none are set.
+var fullRange = sitter.Range{
+	StartByte:  0,
+	EndByte:    math.MaxUint32,
+	StartPoint: sitter.Point{Row: 0, Column: 0},
+	EndPoint:   sitter.Point{Row: math.MaxUint32, Column: math.MaxUint32},
+}
+
+func (r Range) sitter() sitter.Range {
+	return sitter.Range{
+		StartByte:  r.StartByte,
+		EndByte:    r.EndByte,
+		StartPoint: sitter.Point{Row: r.StartPoint.Row, Column: r.StartPoint.Column},
+		EndPoint:   sitter.Point{Row: r.EndPoint.Row, Column: r.EndPoint.Column},
+	}
+}
+
+// Range returns the span of the node. Its points have byte columns, whatever
+// ColumnEncoding the tree uses; see LSPRange for UTF-16 columns.
+func (n *Node) Range() Range {
+	ts := n.live()
+	start, end := ts.StartPoint(), ts.EndPoint()
+	return Range{
+		StartByte:  ts.StartByte(),
+		EndByte:    ts.EndByte(),
+		StartPoint: Point{Row: start.Row, Column: start.Column},
+		EndPoint:   Point{Row: end.Row, Column: end.Column},
+	}
+}
+
+// LSPPosition is a position in a text document as the Language Server
+// Protocol encodes it: a zero-based line and a character offset counted in
+// UTF-16 code units.
+type LSPPosition struct {
+	Line      uint32 `json:"line"`
+	Character uint32 `json:"character"`
+}
+
+// LSPRange is a span of a text document in LSP form. It marshals to JSON as
+// {"start": {"line": 0, "character": 4}, "end": ...}.
+type LSPRange struct {
+	Start LSPPosition `json:"start"`
+	End   LSPPosition `json:"end"`
+}
+
+// LSPRange returns the span of the node with UTF-16 character offsets,
+// ready to send to an LSP client, whatever ColumnEncoding the tree uses.
+func (n *Node) LSPRange() LSPRange {
+	start := n.tree.UTF16PointForByte(n.StartByte())
+	end := n.tree.UTF16PointForByte(n.EndByte())
+	return LSPRange{
+		Start: LSPPosition{Line: start.Row, Character: start.Column},
+		End:   LSPPosition{Line: end.Row, Character: end.Column},
+	}
+}
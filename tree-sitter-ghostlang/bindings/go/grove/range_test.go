@@ -0,0 +1,80 @@
+package grove_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestNodeRange(t *testing.T) {
+	tree := parse(t, "var s = \"😀\"; var t = 1;\n")
+	defer tree.Close()
+	ident := tree.RootNode().NamedChild(1).NamedChild(0).ChildByFieldName("name")
+
+	want := grove.Range{
+		StartByte:  20,
+		EndByte:    21,
+		StartPoint: grove.Point{Row: 0, Column: 20},
+		EndPoint:   grove.Point{Row: 0, Column: 21},
+	}
+	if got := ident.Range(); got != want {
+		t.Errorf("Range() = %+v, want %+v", got, want)
+	}
+	wantLSP := grove.LSPRange{
+		Start: grove.LSPPosition{Line: 0, Character: 18},
+		End:   grove.LSPPosition{Line: 0, Character: 19},
+	}
+	if got := ident.LSPRange(); got != wantLSP {
+		t.Errorf("LSPRange() = %+v, want %+v", got, wantLSP)
+	}
+}
+
+func TestNodeLSPRangeAcrossEmoji(t *testing.T) {
+	// The string literal starts before one emoji and ends after another on
+	// the next line; each counts as two UTF-16 code units.
+	tree := parse(t, "var s = \"😀a\n😀b\";\n")
+	defer tree.Close()
+	lit := tree.RootNode().NamedDescendantForByteRange(8, 9)
+	for lit.Kind() != "string_literal" {
+		lit = lit.Parent()
+	}
+
+	got := lit.LSPRange()
+	want := grove.LSPRange{
+		Start: grove.LSPPosition{Line: 0, Character: 8},
+		End:   grove.LSPPosition{Line: 1, Character: 4},
+	}
+	if got != want {
+		t.Errorf("LSPRange() = %+v, want %+v", got, want)
+	}
+	if r := lit.Range(); r.EndPoint != (grove.Point{Row: 1, Column: 6}) {
+		t.Errorf("Range().EndPoint = %+v, want byte column 6", r.EndPoint)
+	}
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); s != `{"start":{"line":0,"character":8},"end":{"line":1,"character":4}}` {
+		t.Errorf("LSPRange JSON = %s", s)
+	}
+}
+
+func TestNodeLSPRangeIgnoresColumnEncoding(t *testing.T) {
+	p := newParser(t, ghostlang(t), grove.WithColumnEncoding(grove.ColumnRunes))
+	defer p.Close()
+	tree, err := p.Parse(context.Background(), []byte("var s = \"😀\"; var t = 1;\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+	ident := tree.RootNode().NamedChild(1).NamedChild(0).ChildByFieldName("name")
+	if got := ident.LSPRange().Start.Character; got != 18 {
+		t.Errorf("LSPRange().Start.Character = %d with rune columns, want 18", got)
+	}
+	if got := ident.Range().StartPoint.Column; got != 20 {
+		t.Errorf("Range().StartPoint.Column = %d with rune columns, want byte column 20", got)
+	}
+}
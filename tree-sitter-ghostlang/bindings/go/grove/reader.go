@@ -0,0 +1,97 @@
+package grove
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+const (
+	// readerChunkSize caps the bytes handed to tree-sitter per read
+	// callback, since go-tree-sitter copies each chunk into C memory.
+	readerChunkSize = 4096
+	// readerPollInterval is how often ParseReader checks its context when
+	// the parser has no poll interval of its own.
+	readerPollInterval = 10 * time.Millisecond
+)
+
+// readerInput feeds an io.Reader to tree-sitter's input callback, keeping
+// everything it has read so the tree can still serve node text.
+type readerInput struct {
+	ctx context.Context
+	r   io.Reader
+	buf []byte
+	err error // first error from r other than io.EOF
+	eof bool
+}
+
+// read returns the source starting at offset, reading more from r as needed.
+// tree-sitter may ask for earlier offsets again, which are served from buf.
+func (in *readerInput) read(offset uint32, _ sitter.Point) []byte {
+	for int(offset) >= len(in.buf) && !in.eof {
+		if in.ctx.Err() != nil {
+			return nil
+		}
+		in.fill()
+	}
+	if int(offset) >= len(in.buf) {
+		return nil
+	}
+	end := min(len(in.buf), int(offset)+readerChunkSize)
+	return in.buf[offset:end]
+}
+
+func (in *readerInput) fill() {
+	if cap(in.buf)-len(in.buf) < readerChunkSize {
+		grown := make([]byte, len(in.buf), 2*cap(in.buf)+readerChunkSize)
+		copy(grown, in.buf)
+		in.buf = grown
+	}
+	n, err := in.r.Read(in.buf[len(in.buf):cap(in.buf)])
+	in.buf = in.buf[:len(in.buf)+n]
+	if err != nil {
+		in.eof = true
+		if !errors.Is(err, io.EOF) {
+			in.err = err
+		}
+	}
+}
+
+// ParseReader parses source streamed from r, which tree-sitter pulls through
+// its input callback as it lexes rather than requiring the whole text up
+// front. The bytes read are retained by the returned tree, so Node.Text works
+// as it does for Parse. A read error other than io.EOF is returned instead of
+// a tree. ParseReader honours ctx like Parse.
+func (p *Parser) ParseReader(ctx context.Context, r io.Reader) (*Tree, error) {
+	if p.closed {
+		return nil, ErrClosed
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Drop a cancellation that arrived as the previous parse finished.
+	tsParserClearCancel(p.ts)
+	in := &readerInput{ctx: ctx, r: r}
+	input := sitter.Input{Read: in.read, Encoding: sitter.InputEncodingUTF8}
+	interval := p.poll
+	if interval <= 0 {
+		interval = readerPollInterval
+	}
+	ts, err := p.parsePolling(ctx, interval, func() (*sitter.Tree, error) {
+		return p.ts.ParseInputCtx(context.Background(), nil, input)
+	})
+	if err == nil {
+		err = in.err
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		p.Reset()
+		return nil, err
+	}
+	return newTree(ts, p.lang, in.buf, p.columns, p.ranges), nil
+}
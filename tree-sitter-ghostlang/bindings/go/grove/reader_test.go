@@ -0,0 +1,85 @@
+package grove_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestParseReaderMatchesParse(t *testing.T) {
+	src := largeSource(200)
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	want, err := p.Parse(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, r := range map[string]io.Reader{
+		"whole":    bytes.NewReader(src),
+		"one-byte": iotest.OneByteReader(bytes.NewReader(src)),
+	} {
+		tree, err := p.ParseReader(context.Background(), r)
+		if err != nil {
+			t.Fatalf("%s: ParseReader: %v", name, err)
+		}
+		if tree.String() != want.String() {
+			t.Errorf("%s: tree differs from Parse", name)
+		}
+		if !bytes.Equal(tree.Source(), src) {
+			t.Errorf("%s: tree retained %d of %d source bytes", name, len(tree.Source()), len(src))
+		}
+		last := tree.RootNode().NamedChild(tree.RootNode().NamedChildCount() - 1)
+		if got, want := last.Utf8Text(), string(src[last.StartByte():last.EndByte()]); got != want {
+			t.Errorf("%s: Utf8Text() = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestParseReaderError(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	boom := errors.New("boom")
+	r := io.MultiReader(bytes.NewReader([]byte("var x = 1;")), iotest.ErrReader(boom))
+	if tree, err := p.ParseReader(context.Background(), r); tree != nil || !errors.Is(err, boom) {
+		t.Errorf("ParseReader = %v, %v; want nil, %v", tree, err, boom)
+	}
+}
+
+func TestParseReaderAfterLateCancel(t *testing.T) {
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+
+	// As in TestParseAfterLateCancel, the cancellation is still pending when
+	// the parse succeeds.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.SetLogger(func(grove.LogKind, string) {
+		if ctx.Err() == nil {
+			cancel()
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	tree, err := p.Parse(ctx, []byte("var x = 5;"))
+	if err != nil {
+		t.Skipf("the cancellation stopped the parse: %v", err)
+	}
+	tree.Close()
+	p.SetLogger(nil)
+
+	tree, err = p.ParseReader(context.Background(), bytes.NewReader(largeSource(200)))
+	if err != nil {
+		t.Fatalf("ParseReader after a late cancellation = %v", err)
+	}
+	defer tree.Close()
+	if tree.HasError() {
+		t.Errorf("parse after a late cancellation has errors")
+	}
+}
@@ -0,0 +1,179 @@
+package grove
+
+import (
+	"bytes"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Registry maps language names and file extensions to languages. Lookups are
+// case-insensitive. A Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	langs   map[string]*Language
+	exts    map[string]*Language
+	interps map[string]*Language
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		langs:   make(map[string]*Language),
+		exts:    make(map[string]*Language),
+		interps: make(map[string]*Language),
+	}
+}
+
+// DefaultRegistry is the registry grammar packages register into from init.
+var DefaultRegistry = NewRegistry()
+
+// Register adds lang under name, replacing any language previously
+// registered under the same name.
+func (r *Registry) Register(name string, lang *Language) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.langs[strings.ToLower(name)] = lang
+}
+
+// RegisterByExtension associates a file extension such as ".gza" with lang.
+// The leading dot is optional.
+func (r *Registry) RegisterByExtension(ext string, lang *Language) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exts[normalizeExt(ext)] = lang
+}
+
+// RegisterByInterpreter associates an interpreter named on a "#!" line,
+// such as "ghostlang" in "#!/usr/bin/env ghostlang", with lang.
+func (r *Registry) RegisterByInterpreter(interpreter string, lang *Language) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interps[strings.ToLower(interpreter)] = lang
+}
+
+// Get returns the language registered under name.
+func (r *Registry) Get(name string) (*Language, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lang, ok := r.langs[strings.ToLower(name)]
+	return lang, ok
+}
+
+// ForExtension returns the language registered for ext.
+func (r *Registry) ForExtension(ext string) (*Language, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lang, ok := r.exts[normalizeExt(ext)]
+	return lang, ok
+}
+
+// ForFilename returns the language registered for the extension of filename.
+func (r *Registry) ForFilename(filename string) (*Language, bool) {
+	ext := filepath.Ext(filename)
+	if ext == "" {
+		return nil, false
+	}
+	return r.ForExtension(ext)
+}
+
+// ForShebang returns the language registered for the interpreter named on
+// the "#!" first line of src, looking past /usr/bin/env and its options.
+func (r *Registry) ForShebang(src []byte) (*Language, bool) {
+	interp := shebangInterpreter(src)
+	if interp == "" {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	lang, ok := r.interps[strings.ToLower(interp)]
+	return lang, ok
+}
+
+// Detect returns the language for a file, by its extension or, failing
+// that, by its "#!" line.
+func (r *Registry) Detect(filename string, src []byte) (*Language, bool) {
+	if lang, ok := r.ForFilename(filename); ok {
+		return lang, true
+	}
+	return r.ForShebang(src)
+}
+
+// Names returns the registered language names, lower-cased and sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.langs))
+	for name := range r.langs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Extensions returns the registered file extensions in sorted order.
+func (r *Registry) Extensions() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	exts := make([]string, 0, len(r.exts))
+	for ext := range r.exts {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// shebangInterpreter returns the base name of the interpreter on the "#!"
+// line opening src, or "".
+func shebangInterpreter(src []byte) string {
+	if !bytes.HasPrefix(src, []byte("#!")) {
+		return ""
+	}
+	line, _, _ := bytes.Cut(src[2:], []byte("\n"))
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+	interp := path.Base(fields[0])
+	if interp == "env" {
+		// Skip env's options and VAR=value assignments.
+		interp = ""
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") && !strings.Contains(f, "=") {
+				interp = path.Base(f)
+				break
+			}
+		}
+	}
+	return interp
+}
+
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// Register adds lang to DefaultRegistry.
+func Register(name string, lang *Language) { DefaultRegistry.Register(name, lang) }
+
+// RegisterByExtension associates ext with lang in DefaultRegistry.
+func RegisterByExtension(ext string, lang *Language) { DefaultRegistry.RegisterByExtension(ext, lang) }
+
+// RegisterByInterpreter associates interpreter with lang in DefaultRegistry.
+func RegisterByInterpreter(interpreter string, lang *Language) {
+	DefaultRegistry.RegisterByInterpreter(interpreter, lang)
+}
+
+// Get looks up name in DefaultRegistry.
+func Get(name string) (*Language, bool) { return DefaultRegistry.Get(name) }
+
+// ForFilename looks up the extension of filename in DefaultRegistry.
+func ForFilename(filename string) (*Language, bool) { return DefaultRegistry.ForFilename(filename) }
+
+// Names returns the language names registered in DefaultRegistry.
+func Names() []string { return DefaultRegistry.Names() }
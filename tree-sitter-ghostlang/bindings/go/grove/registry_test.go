@@ -0,0 +1,47 @@
+package grove_test
+
+import (
+	"reflect"
+	"testing"
+
+	tree_sitter_ghostlang "github.com/tree-sitter/tree-sitter-ghostlang"
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	r := grove.NewRegistry()
+	lang, err := grove.NewLanguage("Ghostlang", tree_sitter_ghostlang.Language())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Register("Ghostlang", lang)
+	r.RegisterByExtension("GZA", lang)
+
+	for _, name := range []string{"ghostlang", "GHOSTLANG", "Ghostlang"} {
+		if got, ok := r.Get(name); !ok || got != lang {
+			t.Errorf("Get(%q) = %v, %v", name, got, ok)
+		}
+	}
+	if _, ok := r.Get("python"); ok {
+		t.Errorf("Get(python) unexpectedly found a language")
+	}
+	if got, ok := r.ForFilename("plugins/init.Gza"); !ok || got != lang {
+		t.Errorf("ForFilename = %v, %v", got, ok)
+	}
+	if _, ok := r.ForFilename("Makefile"); ok {
+		t.Errorf("ForFilename(Makefile) unexpectedly found a language")
+	}
+	if got := r.Names(); !reflect.DeepEqual(got, []string{"ghostlang"}) {
+		t.Errorf("Names() = %v", got)
+	}
+}
+
+func TestDefaultRegistryHasGhostlang(t *testing.T) {
+	lang, ok := grove.Get("ghostlang")
+	if !ok {
+		t.Fatalf("ghostlang is not registered")
+	}
+	if got, ok := grove.ForFilename("script.gza"); !ok || got != lang {
+		t.Errorf("ForFilename(script.gza) = %v, %v", got, ok)
+	}
+}
@@ -0,0 +1,92 @@
+package grove
+
+import (
+	"sort"
+	"strings"
+)
+
+// ANSITheme maps highlight capture names to ANSI SGR parameters, such as
+// "31" for red or "1;34" for bold blue. Like Highlighter classes, a capture
+// without an entry of its own uses the entry of its longest dotted prefix.
+type ANSITheme map[string]string
+
+// DefaultANSITheme colours the captures of the Ghostlang highlights query
+// with the basic sixteen terminal colours.
+var DefaultANSITheme = ANSITheme{
+	"comment":          "90",
+	"keyword":          "35",
+	"string":           "32",
+	"string.escape":    "36",
+	"number":           "33",
+	"boolean":          "33",
+	"constant.builtin": "33",
+	"function":         "34",
+	"function.builtin": "1;34",
+	"property":         "36",
+	"parameter":        "3",
+	"error":            "1;31",
+}
+
+func (theme ANSITheme) code(capture string) string {
+	for name := capture; name != ""; {
+		if code, ok := theme[name]; ok {
+			return code
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return ""
+}
+
+// RenderANSI returns src with the highlighted parts wrapped in ANSI colour
+// escapes from theme, for printing to a terminal. Text that no span covers,
+// or whose capture the theme has no code for, is left as is. spans need not
+// come from a Highlighter: they may be unsorted and may nest or overlap, and
+// where they do the innermost span colours the text. Colours are reset at
+// the end of every line and reapplied on the next, so that pagers showing
+// part of a multi-line span still colour it.
+func RenderANSI(src []byte, spans []HighlightSpan, theme ANSITheme) string {
+	captures := make([]highlightCapture, 0, len(spans))
+	for i, s := range spans {
+		start, end := min(s.StartByte, uint32(len(src))), min(s.EndByte, uint32(len(src)))
+		if start < end {
+			captures = append(captures, highlightCapture{start: start, end: end, index: uint32(i), name: s.Capture})
+		}
+	}
+	sort.SliceStable(captures, func(i, j int) bool {
+		if captures[i].start != captures[j].start {
+			return captures[i].start < captures[j].start
+		}
+		return captures[i].end > captures[j].end
+	})
+
+	var b strings.Builder
+	b.Grow(len(src))
+	var pos uint32
+	for _, s := range (&Highlighter{}).resolve(captures) {
+		b.Write(src[pos:s.StartByte])
+		pos = s.EndByte
+		code := theme.code(s.Capture)
+		if code == "" {
+			b.Write(src[s.StartByte:s.EndByte])
+			continue
+		}
+		lines := strings.SplitAfter(string(src[s.StartByte:s.EndByte]), "\n")
+		for _, line := range lines {
+			text, newline := strings.CutSuffix(line, "\n")
+			if text != "" {
+				b.WriteString("\x1b[" + code + "m")
+				b.WriteString(text)
+				b.WriteString(ansiReset)
+			}
+			if newline {
+				b.WriteByte('\n')
+			}
+		}
+	}
+	b.Write(src[pos:])
+	return b.String()
+}
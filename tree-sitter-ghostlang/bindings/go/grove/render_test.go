@@ -0,0 +1,74 @@
+package grove_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestRenderANSI(t *testing.T) {
+	src := []byte("var s = \"a\\nb\";")
+	theme := grove.ANSITheme{"keyword": "35", "string": "32", "string.escape": "1;36"}
+	spans := []grove.HighlightSpan{
+		// Out of order, nested and with a capture the theme lacks.
+		{StartByte: 8, EndByte: 14, Capture: "string"},
+		{StartByte: 10, EndByte: 12, Capture: "string.escape"},
+		{StartByte: 0, EndByte: 3, Capture: "keyword"},
+		{StartByte: 4, EndByte: 5, Capture: "variable"},
+	}
+	got := grove.RenderANSI(src, spans, theme)
+	want := "\x1b[35mvar\x1b[0m s = \x1b[32m\"a\x1b[0m\x1b[1;36m\\n\x1b[0m\x1b[32mb\"\x1b[0m;"
+	if got != want {
+		t.Errorf("RenderANSI =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderANSIMultiLine(t *testing.T) {
+	src := []byte("x /* one\ntwo */ y")
+	spans := []grove.HighlightSpan{{StartByte: 2, EndByte: 15, Capture: "comment.block"}}
+	got := grove.RenderANSI(src, spans, grove.ANSITheme{"comment": "90"})
+	want := "x \x1b[90m/* one\x1b[0m\n\x1b[90mtwo */\x1b[0m y"
+	if got != want {
+		t.Errorf("RenderANSI =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestRenderANSIHighlighter(t *testing.T) {
+	source, err := os.ReadFile("../../../queries/highlights.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(t), string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := []byte("// greet\nfunction greet(name) {\n  return \"hi \" + name;\n}\n")
+	spans, err := grove.NewHighlighter(q, nil).Highlight(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := grove.RenderANSI(src, spans, grove.DefaultANSITheme)
+	if plain := stripANSI(out); plain != string(src) {
+		t.Errorf("rendered text differs from the source:\n%q", plain)
+	}
+	for _, want := range []string{"\x1b[90m// greet\x1b[0m", "\x1b[35mfunction\x1b[0m", "\x1b[32m\"hi \"\x1b[0m"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output lacks %q:\n%q", want, out)
+		}
+	}
+}
+
+// stripANSI removes SGR escape sequences.
+func stripANSI(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "\x1b[")
+		if i < 0 {
+			return b.String() + s
+		}
+		b.WriteString(s[:i])
+		s = s[i+strings.IndexByte(s[i:], 'm')+1:]
+	}
+}
@@ -0,0 +1,85 @@
+package grove
+
+import (
+	"bytes"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Scope classifies a position in source text.
+type Scope int
+
+const (
+	// ScopeCode is ordinary code, including whitespace between tokens.
+	ScopeCode Scope = iota
+	// ScopeComment is inside a comment.
+	ScopeComment
+	// ScopeString is inside a string literal.
+	ScopeString
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeComment:
+		return "comment"
+	case ScopeString:
+		return "string"
+	}
+	return "code"
+}
+
+// ScopeAt classifies the byte at offset by the innermost comment or string
+// node containing it, using the node kinds configured for the tree's
+// language. A node contains the bytes from its start up to but excluding its
+// end, so the opening quote of a string is in the string and the offset just
+// after its closing quote is not. The end of the source is in a comment or
+// string left open there, such as a final line comment.
+func (t *Tree) ScopeAt(offset uint32) Scope {
+	atEnd := offset > 0 && int(offset) == len(t.src)
+	if atEnd {
+		offset--
+	}
+	d := t.RootNode().DescendantForByteRange(offset, offset+1)
+	if d == nil {
+		return ScopeCode
+	}
+	lang := t.lang
+	for n := d.ts; n != nil; n = n.Parent() {
+		if offset < n.StartByte() || offset >= n.EndByte() {
+			continue
+		}
+		switch kind := n.Type(); {
+		case lang.commentKinds[kind] && !(atEnd && t.closedComment(n)):
+			return ScopeComment
+		case lang.stringKinds[kind] && !(atEnd && closedString(n)):
+			return ScopeString
+		}
+	}
+	return ScopeCode
+}
+
+// closedComment reports whether n is a block comment with its closing */.
+func (t *Tree) closedComment(n *sitter.Node) bool {
+	text := t.src[n.StartByte():n.EndByte()]
+	return len(text) >= 4 && bytes.HasPrefix(text, []byte("/*")) && bytes.HasSuffix(text, []byte("*/"))
+}
+
+// closedString reports whether n ends with a closing delimiter token.
+func closedString(n *sitter.Node) bool {
+	count := int(n.ChildCount())
+	if count < 2 {
+		return false
+	}
+	last := n.Child(count - 1)
+	return !last.IsNamed() && !last.IsMissing()
+}
+
+// InComment reports whether offset is inside a comment.
+func (t *Tree) InComment(offset uint32) bool {
+	return t.ScopeAt(offset) == ScopeComment
+}
+
+// InString reports whether offset is inside a string literal.
+func (t *Tree) InString(offset uint32) bool {
+	return t.ScopeAt(offset) == ScopeString
+}
@@ -0,0 +1,89 @@
+package grove_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestScopeAt(t *testing.T) {
+	src := `var s = "a b";  // note "q"` + "\n/* block */ f(s);"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	at := func(marker string, skip int) uint32 {
+		t.Helper()
+		i := strings.Index(src, marker)
+		if i < 0 {
+			t.Fatalf("no %q in source", marker)
+		}
+		return uint32(i + skip)
+	}
+	tests := []struct {
+		name   string
+		offset uint32
+		want   grove.Scope
+	}{
+		{"keyword", at("var", 0), grove.ScopeCode},
+		{"opening quote", at(`"a`, 0), grove.ScopeString},
+		{"space in string", at(" b", 0), grove.ScopeString},
+		{"closing quote", at(`";`, 0), grove.ScopeString},
+		{"after closing quote", at(`";`, 1), grove.ScopeCode},
+		{"whitespace between tokens", at(";  //", 1), grove.ScopeCode},
+		{"line comment start", at("// note", 0), grove.ScopeComment},
+		{"string-like text in comment", at(`"q"`, 1), grove.ScopeComment},
+		{"block comment", at("block", 0), grove.ScopeComment},
+		{"call", at("f(s)", 0), grove.ScopeCode},
+		{"end of source", uint32(len(src)), grove.ScopeCode},
+	}
+	for _, tt := range tests {
+		if got := tree.ScopeAt(tt.offset); got != tt.want {
+			t.Errorf("%s: ScopeAt(%d) = %v, want %v", tt.name, tt.offset, got, tt.want)
+		}
+	}
+	if !tree.InString(at(`"a`, 1)) || tree.InComment(at(`"a`, 1)) {
+		t.Errorf("InString/InComment disagree with ScopeAt inside the string")
+	}
+	if !tree.InComment(at("note", 0)) || tree.InString(at("note", 0)) {
+		t.Errorf("InString/InComment disagree with ScopeAt inside the comment")
+	}
+}
+
+func TestScopeAtEndOfSource(t *testing.T) {
+	for _, tt := range []struct {
+		src  string
+		want grove.Scope
+	}{
+		{"var a = 1; // note", grove.ScopeComment},
+		{"var a = 1; /* note */", grove.ScopeCode},
+		{`var s = "abc"`, grove.ScopeCode},
+		{"var a = 1;", grove.ScopeCode},
+		{"", grove.ScopeCode},
+	} {
+		tree := parse(t, tt.src)
+		if got := tree.ScopeAt(uint32(len(tt.src))); got != tt.want {
+			t.Errorf("ScopeAt(end) of %q = %v, want %v", tt.src, got, tt.want)
+		}
+		tree.Close()
+	}
+}
+
+func TestScopeKindsPerLanguage(t *testing.T) {
+	lang, err := grove.NewLanguage("ghostlang-bare", ghostlang(t).Pointer(), grove.WithStringKinds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := newParser(t, lang)
+	defer p.Close()
+	src := `var s = "x";`
+	tree, err := p.Parse(context.Background(), []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+	if got := tree.ScopeAt(uint32(strings.Index(src, "x"))); got != grove.ScopeCode {
+		t.Errorf("ScopeAt with no string kinds = %v, want code", got)
+	}
+}
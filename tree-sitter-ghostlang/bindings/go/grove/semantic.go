@@ -0,0 +1,122 @@
+package grove
+
+import "strings"
+
+// SemanticToken is one entry of an LSP semantic tokens response, in its
+// relative encoding: DeltaLine is the line offset from the previous token,
+// and DeltaStart is the column offset from the previous token's start when
+// both are on the same line, or from the start of the line otherwise.
+type SemanticToken struct {
+	DeltaLine      uint32
+	DeltaStart     uint32
+	Length         uint32
+	TokenType      uint32
+	TokenModifiers uint32
+}
+
+// SemanticTokenizer produces LSP semantic tokens from a highlights query.
+// Captures resolve as they do for a Highlighter, with captures that map to no
+// token type ignored.
+type SemanticTokenizer struct {
+	highlighter *Highlighter
+	modifiers   map[string]uint32
+	columns     ColumnEncoding
+}
+
+// SemanticTokenOption configures a SemanticTokenizer.
+type SemanticTokenOption func(*SemanticTokenizer)
+
+// WithTokenModifiers maps dotted capture name segments to LSP token modifier
+// bit indexes. With {"readonly": 0}, a @variable.readonly capture gets the
+// type of "variable" and modifier bit 0.
+func WithTokenModifiers(modifiers map[string]uint32) SemanticTokenOption {
+	return func(s *SemanticTokenizer) { s.modifiers = modifiers }
+}
+
+// WithTokenColumnEncoding sets how token columns and lengths are measured.
+// The default is ColumnUTF16, LSP's default position encoding.
+func WithTokenColumnEncoding(enc ColumnEncoding) SemanticTokenOption {
+	return func(s *SemanticTokenizer) { s.columns = enc }
+}
+
+// NewSemanticTokenizer returns a semantic tokenizer for query. types maps
+// capture names to LSP token type indexes, falling back through dotted
+// prefixes like the classes of a Highlighter.
+func NewSemanticTokenizer(query *Query, types map[string]uint32, opts ...SemanticTokenOption) *SemanticTokenizer {
+	classes := make(map[string]int, len(types))
+	for name, t := range types {
+		classes[name] = int(t)
+	}
+	s := &SemanticTokenizer{
+		highlighter: NewHighlighter(query, classes),
+		columns:     ColumnUTF16,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SemanticTokens returns the semantic tokens of tree in document order. A
+// capture spanning several lines yields one token per line, since LSP tokens
+// cannot span lines.
+func (s *SemanticTokenizer) SemanticTokens(tree *Tree) []SemanticToken {
+	h := s.highlighter
+	captures := h.collect(tree.RootNode())
+	typed := captures[:0]
+	for _, hc := range captures {
+		if h.classFor(hc.name) >= 0 {
+			typed = append(typed, hc)
+		}
+	}
+
+	lines := tree.lines()
+	var tokens []SemanticToken
+	var prevLine, prevStart uint32
+	for _, span := range h.resolve(typed) {
+		mods := s.modifiersFor(span.Capture)
+		for row, last := lines.row(span.StartByte), lines.row(span.EndByte); row <= last; row++ {
+			lineStart, lineEnd := lines.lineBounds(row)
+			start, end := max(span.StartByte, lineStart), min(span.EndByte, lineEnd)
+			if start >= end {
+				continue
+			}
+			line := uint32(row)
+			col := lines.columnOf(lineStart, start, s.columns)
+			tok := SemanticToken{
+				DeltaLine:      line - prevLine,
+				DeltaStart:     col,
+				Length:         lines.columnOf(lineStart, end, s.columns) - col,
+				TokenType:      uint32(span.Class),
+				TokenModifiers: mods,
+			}
+			if len(tokens) > 0 && line == prevLine {
+				tok.DeltaStart = col - prevStart
+			}
+			tokens = append(tokens, tok)
+			prevLine, prevStart = line, col
+		}
+	}
+	return tokens
+}
+
+func (s *SemanticTokenizer) modifiersFor(capture string) uint32 {
+	var mods uint32
+	segments := strings.Split(capture, ".")
+	for _, seg := range segments[1:] {
+		if bit, ok := s.modifiers[seg]; ok {
+			mods |= 1 << bit
+		}
+	}
+	return mods
+}
+
+// EncodeSemanticTokens flattens tokens into the integer array of an LSP
+// SemanticTokens result.
+func EncodeSemanticTokens(tokens []SemanticToken) []uint32 {
+	data := make([]uint32, 0, 5*len(tokens))
+	for _, t := range tokens {
+		data = append(data, t.DeltaLine, t.DeltaStart, t.Length, t.TokenType, t.TokenModifiers)
+	}
+	return data
+}
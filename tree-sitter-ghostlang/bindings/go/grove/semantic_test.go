@@ -0,0 +1,50 @@
+package grove_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	q, err := grove.NewQuery(ghostlang(t), `
+(comment) @comment
+(identifier) @variable
+(variable_declaration name: (identifier) @variable.readonly)
+(function_declaration name: (identifier) @function)
+(string_literal) @string
+"var" @keyword`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := map[string]uint32{"variable": 0, "function": 1, "comment": 2, "keyword": 3, "string": 4}
+	mods := map[string]uint32{"readonly": 2}
+	src := "var x = \"é\";\n/* a\n   bc */ function f(y) { y; }\n"
+	tree := parse(t, src)
+	defer tree.Close()
+
+	tokens := grove.NewSemanticTokenizer(q, types, grove.WithTokenModifiers(mods)).SemanticTokens(tree)
+	want := []grove.SemanticToken{
+		{0, 0, 3, 3, 0},  // var
+		{0, 4, 1, 0, 4},  // x, readonly
+		{0, 4, 3, 4, 0},  // "é", in UTF-16 code units
+		{1, 0, 4, 2, 0},  // /* a
+		{1, 0, 8, 2, 0},  //    bc */
+		{0, 18, 1, 1, 0}, // f
+		{0, 2, 1, 0, 0},  // y
+		{0, 5, 1, 0, 0},  // y
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint(want) {
+		t.Errorf("SemanticTokens =\n%v\nwant\n%v", tokens, want)
+	}
+	data := grove.EncodeSemanticTokens(tokens)
+	if len(data) != 5*len(tokens) || data[5] != 0 || data[6] != 4 {
+		t.Errorf("EncodeSemanticTokens = %v", data)
+	}
+
+	again := grove.NewSemanticTokenizer(q, types, grove.WithTokenModifiers(mods)).SemanticTokens(tree)
+	if fmt.Sprint(again) != fmt.Sprint(tokens) {
+		t.Errorf("SemanticTokens is not deterministic")
+	}
+}
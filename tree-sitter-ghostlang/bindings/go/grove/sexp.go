@@ -0,0 +1,95 @@
+package grove
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// SExpOptions controls the output of Node.SExp.
+type SExpOptions struct {
+	// IncludeAnonymous adds anonymous tokens such as "var" and ";".
+	IncludeAnonymous bool
+	// IncludePositions annotates each node with its [row, column] range in
+	// the format of `tree-sitter parse`.
+	IncludePositions bool
+}
+
+// ToSExp returns the node as a single-line S-expression with field names,
+// such as (function_declaration name: (identifier) body: (block_statement)).
+// The output matches tree-sitter's ts_node_string.
+func (n *Node) ToSExp() string {
+	return n.SExp(SExpOptions{})
+}
+
+// String returns the S-expression of the tree's root node.
+func (t *Tree) String() string {
+	return t.RootNode().ToSExp()
+}
+
+// SExp returns the node as a single-line S-expression using opts.
+func (n *Node) SExp(opts SExpOptions) string {
+	var b strings.Builder
+	c := sitter.NewTreeCursor(n.live())
+	defer c.Close()
+
+	depth := 0     // depth of the cursor below n
+	var open []int // depths of nodes whose closing paren is pending
+	for {
+		node := c.CurrentNode()
+		visible := node.IsNamed() || node.IsMissing() || opts.IncludeAnonymous
+		if visible {
+			if b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			if field := c.CurrentFieldName(); field != "" && depth > 0 {
+				b.WriteString(field)
+				b.WriteString(": ")
+			}
+			writeSExpNode(&b, node, opts)
+		}
+		if c.GoToFirstChild() {
+			if visible && (node.IsNamed() || node.IsMissing()) {
+				open = append(open, depth)
+			}
+			depth++
+			continue
+		}
+		if visible && (node.IsNamed() || node.IsMissing()) {
+			b.WriteByte(')')
+		}
+		for !c.GoToNextSibling() {
+			if !c.GoToParent() {
+				return b.String()
+			}
+			depth--
+			if len(open) > 0 && open[len(open)-1] == depth {
+				open = open[:len(open)-1]
+				b.WriteByte(')')
+			}
+		}
+	}
+}
+
+func writeSExpNode(b *strings.Builder, n *sitter.Node, opts SExpOptions) {
+	switch {
+	case n.IsMissing():
+		b.WriteString("(MISSING ")
+		if n.IsNamed() {
+			b.WriteString(n.Type())
+		} else {
+			b.WriteString(strconv.Quote(n.Type()))
+		}
+	case n.IsNamed():
+		b.WriteByte('(')
+		b.WriteString(n.Type())
+	default:
+		b.WriteString(strconv.Quote(n.Type()))
+	}
+	if opts.IncludePositions {
+		s, e := n.StartPoint(), n.EndPoint()
+		fmt.Fprintf(b, " [%d, %d] - [%d, %d]", s.Row, s.Column, e.Row, e.Column)
+	}
+}
@@ -0,0 +1,65 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// normalize collapses the whitespace of a corpus-style S-expression.
+func normalize(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(strings.ReplaceAll(s, "( ", "("), " )", ")")
+}
+
+func TestToSExpMatchesCorpus(t *testing.T) {
+	// From test/corpus/basic.txt, "Variable declaration".
+	want := normalize(`
+(source_file
+  (statement
+    (variable_declaration
+      name: (identifier)
+      value: (expression
+        (conditional_expression
+          (logical_or_expression
+            (logical_and_expression
+              (equality_expression
+                (relational_expression
+                  (additive_expression
+                    (multiplicative_expression
+                      (unary_expression
+                        (postfix_expression
+                          (primary_expression
+                            (number_literal)))))))))))))))`)
+	tree := parse(t, "var x = 5;\n")
+	if got := tree.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSExpOptions(t *testing.T) {
+	tree := parse(t, "f(a b);")
+	got := tree.RootNode().NamedChild(0).SExp(grove.SExpOptions{IncludeAnonymous: true, IncludePositions: true})
+	for _, want := range []string{
+		`(statement [0, 0] - [0, 7]`,
+		`function: (postfix_expression [0, 0] - [0, 1]`,
+		`"(" [0, 1] - [0, 2]`,
+		`(ERROR [0, 4] - [0, 5] (identifier [0, 4] - [0, 5]))`,
+		`";" [0, 6] - [0, 7]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SExp output lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(tree.String(), `"("`) {
+		t.Errorf("ToSExp included anonymous nodes: %s", tree)
+	}
+}
+
+func TestSExpMissing(t *testing.T) {
+	tree := parse(t, "var x = 1")
+	if got := tree.String(); !strings.Contains(got, `(MISSING ";")`) {
+		t.Errorf("String() = %s, want a (MISSING \";\") node", got)
+	}
+}
@@ -0,0 +1,295 @@
+package grove
+
+// #include "api.h"
+import "C"
+
+import (
+	"runtime"
+	"sync/atomic"
+	"unsafe"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// go-tree-sitter compiles the tree-sitter runtime into the binary but only
+// wraps part of its API, and it keeps its C handles unexported. Grove calls
+// the remaining entry points directly, declared by api.h (copied from the
+// go-tree-sitter version pinned in go.mod), and reaches the handles through
+// the mirrors below. The mirrors must match go-tree-sitter's struct layouts,
+// so bumping that dependency means re-checking them.
+
+const (
+	tsLanguageVersionMax           = C.TREE_SITTER_LANGUAGE_VERSION
+	tsMinCompatibleLanguageVersion = C.TREE_SITTER_MIN_COMPATIBLE_LANGUAGE_VERSION
+)
+
+// sitterBaseTree mirrors sitter.BaseTree.
+type sitterBaseTree struct {
+	c        *C.TSTree
+	isClosed bool
+}
+
+// sitterNode mirrors sitter.Node.
+type sitterNode struct {
+	c C.TSNode
+	t *sitter.Tree
+}
+
+// sitterQuery mirrors sitter.Query.
+type sitterQuery struct {
+	c        *C.TSQuery
+	isClosed bool
+}
+
+// sitterParser mirrors sitter.Parser.
+type sitterParser struct {
+	isClosed bool
+	c        *C.TSParser
+	cancel   *uintptr
+}
+
+func parserHandle(p *sitter.Parser) *C.TSParser {
+	return (*sitterParser)(unsafe.Pointer(p)).c
+}
+
+// tsParserClearCancel clears the cancellation flag that sitter.Parser.ParseCtx
+// sets when its context is done. ParseCtx only clears it after a parse the
+// flag stopped; one set as a parse finished would stop the next parse early.
+func tsParserClearCancel(p *sitter.Parser) {
+	atomic.StoreUintptr((*sitterParser)(unsafe.Pointer(p)).cancel, 0)
+}
+
+func treeHandle(t *sitter.Tree) *C.TSTree {
+	return (*sitterBaseTree)(unsafe.Pointer(t.BaseTree)).c
+}
+
+func nodeHandle(n *sitter.Node) C.TSNode {
+	return (*sitterNode)(unsafe.Pointer(n)).c
+}
+
+// wrapNode returns a sitter.Node for c, which must come from the same tree as
+// like, or nil if c is the null node.
+func wrapNode(like *sitter.Node, c C.TSNode) *sitter.Node {
+	return wrapTreeNode((*sitterNode)(unsafe.Pointer(like)).t, c)
+}
+
+// wrapTreeNode returns a sitter.Node for c, a node of t, or nil if c is the
+// null node.
+func wrapTreeNode(t *sitter.Tree, c C.TSNode) *sitter.Node {
+	if c.id == nil {
+		return nil
+	}
+	return (*sitter.Node)(unsafe.Pointer(&sitterNode{c: c, t: t}))
+}
+
+func cPoint(p Point) C.TSPoint {
+	return C.TSPoint{row: C.uint32_t(p.Row), column: C.uint32_t(p.Column)}
+}
+
+// tsTreeEdit applies edit through ts_tree_edit. sitter.Tree.Edit passes
+// OldEndPoint where NewEndPoint belongs, which corrupts row/column positions
+// after the edit.
+func tsTreeEdit(t *sitter.Tree, edit InputEdit) {
+	e := C.TSInputEdit{
+		start_byte:    C.uint32_t(edit.StartByte),
+		old_end_byte:  C.uint32_t(edit.OldEndByte),
+		new_end_byte:  C.uint32_t(edit.NewEndByte),
+		start_point:   cPoint(edit.StartPoint),
+		old_end_point: cPoint(edit.OldEndPoint),
+		new_end_point: cPoint(edit.NewEndPoint),
+	}
+	C.ts_tree_edit(treeHandle(t), &e)
+}
+
+// tsNodeID returns the address of the subtree backing n.
+func tsNodeID(n *sitter.Node) uintptr {
+	return uintptr(nodeHandle(n).id)
+}
+
+func tsNodeEq(a, b *sitter.Node) bool {
+	return bool(C.ts_node_eq(nodeHandle(a), nodeHandle(b)))
+}
+
+// tsQueryNew compiles source with ts_query_new. sitter.NewQuery is not used
+// because its predicate validation rejects valid one-argument directives such
+// as (#set! injection.include-children).
+func tsQueryNew(lang *Language, source string) (*sitter.Query, uint32, sitter.QueryErrorType) {
+	var offset C.uint32_t
+	var kind C.TSQueryError
+	cs := C.CString(source)
+	defer C.free(unsafe.Pointer(cs))
+	c := C.ts_query_new((*C.TSLanguage)(lang.ptr), cs, C.uint32_t(len(source)), &offset, &kind)
+	if c == nil {
+		return nil, uint32(offset), sitter.QueryErrorType(kind)
+	}
+	q := (*sitter.Query)(unsafe.Pointer(&sitterQuery{c: c}))
+	runtime.SetFinalizer(q, (*sitter.Query).Close)
+	return q, 0, sitter.QueryErrorNone
+}
+
+func queryHandle(q *sitter.Query) *C.TSQuery {
+	return (*sitterQuery)(unsafe.Pointer(q)).c
+}
+
+func tsQueryStartByteForPattern(q *sitter.Query, pattern uint32) uint32 {
+	return uint32(C.ts_query_start_byte_for_pattern(queryHandle(q), C.uint32_t(pattern)))
+}
+
+func tsDescendantForByteRange(n *sitter.Node, start, end uint32, named bool) *sitter.Node {
+	c := nodeHandle(n)
+	if named {
+		return wrapNode(n, C.ts_node_named_descendant_for_byte_range(c, C.uint32_t(start), C.uint32_t(end)))
+	}
+	return wrapNode(n, C.ts_node_descendant_for_byte_range(c, C.uint32_t(start), C.uint32_t(end)))
+}
+
+// tsChangedRanges returns the ranges whose syntactic structure differs
+// between old and new, through ts_tree_get_changed_ranges.
+func tsChangedRanges(old, new *sitter.Tree) []Range {
+	var n C.uint32_t
+	c := C.ts_tree_get_changed_ranges(treeHandle(old), treeHandle(new), &n)
+	if c == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(c))
+	ranges := make([]Range, n)
+	for i, r := range unsafe.Slice(c, int(n)) {
+		ranges[i] = Range{
+			StartByte:  uint32(r.start_byte),
+			EndByte:    uint32(r.end_byte),
+			StartPoint: Point{Row: uint32(r.start_point.row), Column: uint32(r.start_point.column)},
+			EndPoint:   Point{Row: uint32(r.end_point.row), Column: uint32(r.end_point.column)},
+		}
+	}
+	return ranges
+}
+
+func tsLanguage(lang *Language) *C.TSLanguage {
+	return (*C.TSLanguage)(lang.ptr)
+}
+
+func tsLanguageVersion(lang *Language) uint32 {
+	return uint32(C.ts_language_version(tsLanguage(lang)))
+}
+
+func tsSymbolName(lang *Language, id uint16) string {
+	return C.GoString(C.ts_language_symbol_name(tsLanguage(lang), C.TSSymbol(id)))
+}
+
+func tsSymbolForName(lang *Language, name string, named bool) uint16 {
+	cs := C.CString(name)
+	defer C.free(unsafe.Pointer(cs))
+	return uint16(C.ts_language_symbol_for_name(tsLanguage(lang), cs, C.uint32_t(len(name)), C.bool(named)))
+}
+
+func tsFieldCount(lang *Language) uint32 {
+	return uint32(C.ts_language_field_count(tsLanguage(lang)))
+}
+
+func tsFieldName(lang *Language, id uint16) string {
+	return C.GoString(C.ts_language_field_name_for_id(tsLanguage(lang), C.TSFieldId(id)))
+}
+
+func tsFieldIDForName(lang *Language, name string) uint16 {
+	cs := C.CString(name)
+	defer C.free(unsafe.Pointer(cs))
+	return uint16(C.ts_language_field_id_for_name(tsLanguage(lang), cs, C.uint32_t(len(name))))
+}
+
+// tsCursor holds a TSTreeCursor by value, so that moving it calls straight
+// into C without allocating.
+type tsCursor struct {
+	c C.TSTreeCursor
+}
+
+func (c *tsCursor) init(n *sitter.Node) {
+	c.c = C.ts_tree_cursor_new(nodeHandle(n))
+}
+
+func (c *tsCursor) reset(n *sitter.Node) {
+	C.ts_tree_cursor_reset(&c.c, nodeHandle(n))
+}
+
+func (c *tsCursor) gotoFirstChild() bool {
+	return bool(C.ts_tree_cursor_goto_first_child(&c.c))
+}
+
+func (c *tsCursor) gotoNextSibling() bool {
+	return bool(C.ts_tree_cursor_goto_next_sibling(&c.c))
+}
+
+func (c *tsCursor) gotoParent() bool {
+	return bool(C.ts_tree_cursor_goto_parent(&c.c))
+}
+
+func (c *tsCursor) node(t *sitter.Tree) *sitter.Node {
+	return wrapTreeNode(t, C.ts_tree_cursor_current_node(&c.c))
+}
+
+func (c *tsCursor) fieldID() uint16 {
+	return uint16(C.ts_tree_cursor_current_field_id(&c.c))
+}
+
+func (c *tsCursor) delete() {
+	C.ts_tree_cursor_delete(&c.c)
+}
+
+// sitterQueryCursor mirrors the leading field of sitter.QueryCursor.
+type sitterQueryCursor struct {
+	c *C.TSQueryCursor
+}
+
+func queryCursorHandle(qc *sitter.QueryCursor) *C.TSQueryCursor {
+	return (*sitterQueryCursor)(unsafe.Pointer(qc)).c
+}
+
+func tsQueryCursorSetByteRange(qc *sitter.QueryCursor, start, end uint32) {
+	C.ts_query_cursor_set_byte_range(queryCursorHandle(qc), C.uint32_t(start), C.uint32_t(end))
+}
+
+func tsQueryCursorSetMatchLimit(qc *sitter.QueryCursor, limit uint32) {
+	C.ts_query_cursor_set_match_limit(queryCursorHandle(qc), C.uint32_t(limit))
+}
+
+func tsQueryCursorDidExceedMatchLimit(qc *sitter.QueryCursor) bool {
+	return bool(C.ts_query_cursor_did_exceed_match_limit(queryCursorHandle(qc)))
+}
+
+// tsCaptureMatch is the match a query cursor's next capture belongs to. Its
+// captures point into the cursor and are only valid until it moves again.
+type tsCaptureMatch struct {
+	id       uint32
+	pattern  int
+	captures []C.TSQueryCapture
+	current  int // index in captures of the capture being yielded
+}
+
+// tsQueryCursorNextCapture advances qc with ts_query_cursor_next_capture,
+// which yields captures in document order without allocating.
+func tsQueryCursorNextCapture(qc *sitter.QueryCursor) (tsCaptureMatch, bool) {
+	var m C.TSQueryMatch
+	var current C.uint32_t
+	if !C.ts_query_cursor_next_capture(queryCursorHandle(qc), &m, &current) {
+		return tsCaptureMatch{}, false
+	}
+	return tsCaptureMatch{
+		id:       uint32(m.id),
+		pattern:  int(m.pattern_index),
+		captures: unsafe.Slice(m.captures, int(m.capture_count)),
+		current:  int(current),
+	}, true
+}
+
+// index returns the query capture index of the i-th capture.
+func (m tsCaptureMatch) index(i int) uint32 {
+	return uint32(m.captures[i].index)
+}
+
+// node returns the node of the i-th capture, which belongs to t.
+func (m tsCaptureMatch) node(t *sitter.Tree, i int) *sitter.Node {
+	return wrapTreeNode(t, m.captures[i].node)
+}
+
+func tsQueryCursorRemoveMatch(qc *sitter.QueryCursor, id uint32) {
+	C.ts_query_cursor_remove_match(queryCursorHandle(qc), C.uint32_t(id))
+}
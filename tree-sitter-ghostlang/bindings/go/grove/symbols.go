@@ -0,0 +1,54 @@
+package grove
+
+import "strings"
+
+// DocumentSymbol is an entry in a document outline.
+type DocumentSymbol struct {
+	Name string
+	// Kind is the syntax category of the definition, such as "function"
+	// for a @definition.function capture.
+	Kind string
+	// Range spans the whole definition; SelectionRange spans its name.
+	Range          Range
+	SelectionRange Range
+	// Children are the definitions nested inside this one.
+	Children []DocumentSymbol
+}
+
+// DocumentSymbols returns the outline of tree from q, a tags query as used by
+// TagExtractor: each @definition.<kind> capture with a non-empty @name becomes
+// a symbol of that kind, so the query's capture names decide the kinds.
+// Symbols nest by syntactic containment. References are ignored.
+func DocumentSymbols(tree *Tree, q *Query) ([]DocumentSymbol, error) {
+	tags, err := NewTagExtractor(q).Tags(tree)
+	if err != nil {
+		return nil, err
+	}
+	defs := tags[:0]
+	for _, t := range tags {
+		if t.Kind == TagDefinition && strings.TrimSpace(t.Name) != "" {
+			defs = append(defs, t)
+		}
+	}
+	symbols, _ := nestSymbols(defs, 0, ^uint32(0))
+	return symbols, nil
+}
+
+// nestSymbols builds the symbols for defs[i:] that end by end, which must be
+// sorted by start and then by descending end. It returns them with the index
+// of the first definition left over.
+func nestSymbols(defs []Tag, i int, end uint32) ([]DocumentSymbol, int) {
+	var symbols []DocumentSymbol
+	for i < len(defs) && defs[i].Range.EndByte <= end {
+		d := defs[i]
+		s := DocumentSymbol{
+			Name:           d.Name,
+			Kind:           d.Syntax,
+			Range:          d.Range,
+			SelectionRange: d.NameRange,
+		}
+		s.Children, i = nestSymbols(defs, i+1, d.Range.EndByte)
+		symbols = append(symbols, s)
+	}
+	return symbols, i
+}
@@ -0,0 +1,66 @@
+package grove_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func outline(symbols []grove.DocumentSymbol) string {
+	var parts []string
+	for _, s := range symbols {
+		part := s.Kind + " " + s.Name
+		if len(s.Children) > 0 {
+			part += " " + outline(s.Children)
+		}
+		parts = append(parts, part)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	src := `var config = { name: "x", run: 1 };
+function outer(a) {
+  function inner() { var deep = 1; }
+  var count = 2;
+}
+var anon = { };
+`
+	tree := parse(t, src)
+	defer tree.Close()
+
+	symbols, err := grove.DocumentSymbols(tree, tagsQuery(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[variable config, function outer [function inner [variable deep], variable count], variable anon]"
+	if got := outline(symbols); got != want {
+		t.Errorf("DocumentSymbols = %s, want %s", got, want)
+	}
+	outer := symbols[1]
+	if got := src[outer.SelectionRange.StartByte:outer.SelectionRange.EndByte]; got != "outer" {
+		t.Errorf("selection range covers %q, want the name", got)
+	}
+	if got := src[outer.Range.StartByte:outer.Range.EndByte]; !strings.HasPrefix(got, "function outer") || !strings.HasSuffix(got, "}") {
+		t.Errorf("range covers %q, want the whole declaration", got)
+	}
+
+	// Kinds come from capture names, and definitions without a name are
+	// skipped.
+	q, err := grove.NewQuery(ghostlang(t), `
+(variable_declaration name: (identifier) @name) @definition.constant
+(object_member (identifier) @name) @definition.field
+(object_literal) @definition.object`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symbols, err = grove.DocumentSymbols(tree, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "[constant config [field name, field run], constant deep, constant count, constant anon]"
+	if got := outline(symbols); got != want {
+		t.Errorf("DocumentSymbols = %s, want %s", got, want)
+	}
+}
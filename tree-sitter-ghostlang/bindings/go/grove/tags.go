@@ -0,0 +1,200 @@
+package grove
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagKind tells definitions from references.
+type TagKind int
+
+const (
+	// TagDefinition is a symbol being defined, from a @definition.* capture.
+	TagDefinition TagKind = iota
+	// TagReference is a use of a symbol, from a @reference.* capture.
+	TagReference
+)
+
+func (k TagKind) String() string {
+	if k == TagReference {
+		return "reference"
+	}
+	return "definition"
+}
+
+// Tag is a definition of or reference to a named symbol, found by a tags
+// query.
+type Tag struct {
+	// Name is the text of the @name capture.
+	Name string
+	Kind TagKind
+	// Syntax is the category after the kind in the capture name, such as
+	// "function" for @definition.function or "call" for @reference.call.
+	Syntax string
+	// Scope holds the names of the container definitions enclosing the tag,
+	// such as functions and classes, outermost first and joined with dots.
+	// It is empty at the top level.
+	Scope string
+	// Range spans the whole tagged node; NameRange spans just its name.
+	Range     Range
+	NameRange Range
+	// Doc spans the @doc nodes a #select-adjacent! directive kept for the
+	// tag, or is nil if it has none.
+	Doc *Range
+}
+
+// QualifiedName returns the tag's name prefixed by its scope.
+func (t Tag) QualifiedName() string {
+	if t.Scope == "" {
+		return t.Name
+	}
+	return t.Scope + "." + t.Name
+}
+
+// TagExtractor finds definitions and references with a tags query. Each
+// pattern captures the symbol's name as @name and the whole node as
+// @definition.<syntax> or @reference.<syntax>; it may also capture
+// documentation as @doc, narrowed with #select-adjacent! @doc @definition.x
+// to the @doc nodes on the lines directly above the definition.
+type TagExtractor struct {
+	query *Query
+}
+
+// NewTagExtractor returns a tag extractor for query.
+func NewTagExtractor(query *Query) *TagExtractor {
+	return &TagExtractor{query: query}
+}
+
+// Tags returns the tags in tree, in document order. A node matched by
+// several patterns is tagged once.
+func (e *TagExtractor) Tags(tree *Tree) ([]Tag, error) {
+	if tree.Language() != e.query.Language() {
+		return nil, fmt.Errorf("grove: tags query is for %s, tree is %s", e.query.Language().Name(), tree.Language().Name())
+	}
+	type key struct {
+		name uint32
+		kind TagKind
+	}
+	seen := make(map[key]int)
+	var tags []Tag
+	matches := e.query.Matches(tree.RootNode())
+	for m, ok := matches.Next(); ok; m, ok = matches.Next() {
+		tag, ok := e.tag(m)
+		if !ok {
+			continue
+		}
+		k := key{tag.NameRange.StartByte, tag.Kind}
+		if i, dup := seen[k]; dup {
+			if tags[i].Doc == nil {
+				tags[i].Doc = tag.Doc
+			}
+			continue
+		}
+		seen[k] = len(tags)
+		tags = append(tags, tag)
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Range.StartByte != tags[j].Range.StartByte {
+			return tags[i].Range.StartByte < tags[j].Range.StartByte
+		}
+		return tags[i].Range.EndByte > tags[j].Range.EndByte
+	})
+	scopeTags(tags)
+	return tags, nil
+}
+
+// tag builds the tag for a match, reporting false if the match lacks a name
+// or a definition or reference capture.
+func (e *TagExtractor) tag(m QueryMatch) (Tag, bool) {
+	var tag Tag
+	var name, node *Node
+	var index uint32
+	for _, c := range m.Captures {
+		kind, syntax, _ := strings.Cut(c.Name, ".")
+		switch {
+		case c.Name == "name":
+			name = c.Node
+		case kind == "definition" && syntax != "":
+			tag.Kind, tag.Syntax, node, index = TagDefinition, syntax, c.Node, c.Index
+		case kind == "reference" && syntax != "":
+			tag.Kind, tag.Syntax, node, index = TagReference, syntax, c.Node, c.Index
+		}
+	}
+	if name == nil || node == nil {
+		return Tag{}, false
+	}
+	tag.Name = name.Utf8Text()
+	tag.Range = node.Range()
+	tag.NameRange = name.Range()
+	tag.Doc = e.doc(m, node, index)
+	return tag, true
+}
+
+// doc returns the span of the documentation captured for node, applying the
+// pattern's #select-adjacent! directives.
+func (e *TagExtractor) doc(m QueryMatch, node *Node, index uint32) *Range {
+	var docs []*Node
+	adjacent := false
+	for _, d := range e.query.directives[m.PatternIndex] {
+		if d.op == "select-adjacent!" && len(d.args) == 2 && d.args[1].capture == int(index) {
+			adjacent = true
+		}
+	}
+	for _, c := range m.Captures {
+		if c.Name == "doc" {
+			docs = append(docs, c.Node)
+		}
+	}
+	if adjacent {
+		// Keep the run of docs that ends on the line above node, with no
+		// blank lines between them.
+		row := node.ts.StartPoint().Row
+		first := len(docs)
+		for first > 0 {
+			d := docs[first-1]
+			if d.EndByte() > node.StartByte() || d.ts.EndPoint().Row+1 < row {
+				break
+			}
+			row = d.ts.StartPoint().Row
+			first--
+		}
+		docs = docs[first:]
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	r := docs[0].Range()
+	last := docs[len(docs)-1].Range()
+	r.EndByte, r.EndPoint = last.EndByte, last.EndPoint
+	return &r
+}
+
+// scopeSyntaxes are the definition syntaxes that contain other definitions
+// and so scope the tags inside them. A variable's initializer, for one, is
+// not a scope.
+var scopeSyntaxes = map[string]bool{
+	"function":  true,
+	"method":    true,
+	"class":     true,
+	"interface": true,
+	"module":    true,
+	"namespace": true,
+}
+
+// scopeTags fills in the scope of tags, which must be sorted by start byte
+// and then by descending end byte.
+func scopeTags(tags []Tag) {
+	var stack []Tag
+	for i := range tags {
+		for len(stack) > 0 && stack[len(stack)-1].Range.EndByte <= tags[i].Range.StartByte {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 {
+			tags[i].Scope = stack[len(stack)-1].QualifiedName()
+		}
+		if tags[i].Kind == TagDefinition && scopeSyntaxes[tags[i].Syntax] {
+			stack = append(stack, tags[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package grove_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func tagsQuery(t *testing.T) *grove.Query {
+	t.Helper()
+	source, err := os.ReadFile("../../../queries/tags.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := grove.NewQuery(ghostlang(t), string(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q
+}
+
+func TestTags(t *testing.T) {
+	src := `// Unrelated.
+
+// Greets someone.
+// Twice.
+function outer(name) {
+  function inner() { return name; }
+  var count = 2;
+  return inner();
+}
+outer("x");
+`
+	tree := parse(t, src)
+	defer tree.Close()
+
+	tags, err := grove.NewTagExtractor(tagsQuery(t)).Tags(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		kind      grove.TagKind
+		syntax    string
+		qualified string
+	}{
+		{grove.TagDefinition, "function", "outer"},
+		{grove.TagDefinition, "function", "outer.inner"},
+		{grove.TagDefinition, "variable", "outer.count"},
+		{grove.TagReference, "call", "outer.inner"},
+		{grove.TagReference, "call", "outer"},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("got %d tags, want %d: %+v", len(tags), len(want), tags)
+	}
+	for i, w := range want {
+		tag := tags[i]
+		if tag.Kind != w.kind || tag.Syntax != w.syntax || tag.QualifiedName() != w.qualified {
+			t.Errorf("tag %d = %v %s %s, want %v %s %s", i, tag.Kind, tag.Syntax, tag.QualifiedName(), w.kind, w.syntax, w.qualified)
+		}
+		if got := src[tag.NameRange.StartByte:tag.NameRange.EndByte]; got != tag.Name {
+			t.Errorf("tag %d name range covers %q, want %q", i, got, tag.Name)
+		}
+	}
+
+	doc := tags[0].Doc
+	if doc == nil {
+		t.Fatalf("outer has no doc")
+	}
+	if got := src[doc.StartByte:doc.EndByte]; got != "// Greets someone.\n// Twice." {
+		t.Errorf("outer doc = %q", got)
+	}
+	if tags[1].Doc != nil {
+		t.Errorf("inner has doc %+v, want none", tags[1].Doc)
+	}
+}
+
+func TestTagsVariableIsNotAScope(t *testing.T) {
+	tree := parse(t, "var y = f(1);\nfunction g() {\n  var z = h(2);\n}\n")
+	defer tree.Close()
+	tags, err := grove.NewTagExtractor(tagsQuery(t)).Tags(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopes := make(map[string]string)
+	for _, tag := range tags {
+		scopes[tag.Name] = tag.Scope
+	}
+	for name, want := range map[string]string{"y": "", "f": "", "g": "", "z": "g", "h": "g"} {
+		if got, ok := scopes[name]; !ok || got != want {
+			t.Errorf("tag %s has scope %q (found %v), want %q", name, got, ok, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package grove
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Tree is a parsed syntax tree together with the source it was parsed from.
+//
+// A Tree is not safe for concurrent use, even by goroutines that only read
+// it: looking up nodes fills caches inside the tree. To fan work out, give
+// each goroutine its own Copy. Copies share the source and the unchanged
+// subtrees, so a copy and the original, or two copies, may be used at the same
+// time for anything, including Edit and ParseIncremental, as long as each
+// one is used by a single goroutine. Nodes belong to the tree they were
+// obtained from and must stay on that tree's goroutine. Closing one copy does
+// not affect the others.
+//
+// Close frees a tree's C memory, after which the tree and its nodes panic
+// with ErrClosed. A tree that is never closed is freed when it is garbage
+// collected.
+type Tree struct {
+	ts      *sitter.Tree
+	lang    *Language
+	src     []byte
+	columns ColumnEncoding
+	ranges  []Range // included ranges, or nil for the whole source
+	closed  bool
+
+	linesOnce sync.Once
+	lineIndex *lineIndex
+}
+
+func newTree(ts *sitter.Tree, lang *Language, src []byte, columns ColumnEncoding, ranges []Range) *Tree {
+	t := &Tree{ts: ts, lang: lang, src: src, columns: columns, ranges: ranges}
+	runtime.SetFinalizer(t, (*Tree).Close)
+	return t
+}
+
+// live returns the tree-sitter tree, panicking if t is closed.
+func (t *Tree) live() *sitter.Tree {
+	if t.closed {
+		panic(ErrClosed)
+	}
+	return t.ts
+}
+
+// Language returns the language the tree was parsed with.
+func (t *Tree) Language() *Language {
+	return t.lang
+}
+
+// Source returns the source text the tree was parsed from.
+func (t *Tree) Source() []byte {
+	return t.src
+}
+
+// IncludedRanges returns the ranges of the source the tree was parsed from,
+// as set with Parser.SetIncludedRanges, or nil if the whole source was
+// parsed. Node positions are offsets into the whole source either way.
+func (t *Tree) IncludedRanges() []Range {
+	return slices.Clone(t.ranges)
+}
+
+// text returns the included bytes of src[start:end]: the slice itself when
+// it lies within one included range, or else a copy of its parts in the
+// included ranges, joined.
+func (t *Tree) text(start, end uint32) []byte {
+	i, _ := slices.BinarySearchFunc(t.ranges, start, func(r Range, offset uint32) int {
+		return cmp.Compare(r.EndByte, offset+1)
+	})
+	if i == len(t.ranges) || start >= t.ranges[i].StartByte && end <= t.ranges[i].EndByte {
+		return t.src[start:end]
+	}
+	var text []byte
+	for _, r := range t.ranges[i:] {
+		if r.StartByte >= end {
+			break
+		}
+		text = append(text, t.src[max(start, r.StartByte):min(end, r.EndByte)]...)
+	}
+	return text
+}
+
+// RootNode returns the root node of the tree.
+func (t *Tree) RootNode() *Node {
+	return t.node(t.live().RootNode())
+}
+
+// Copy returns an independent copy of the tree, sharing its source, for use
+// on another goroutine. Copying is cheap: it wraps ts_tree_copy, which only
+// takes a reference to the tree's nodes. Editing either tree leaves the
+// other as it was.
+func (t *Tree) Copy() *Tree {
+	return newTree(t.live().Copy(), t.lang, t.src, t.columns, t.ranges)
+}
+
+// Close releases the underlying tree-sitter tree. Calling it again does
+// nothing.
+func (t *Tree) Close() {
+	if !t.closed {
+		t.closed = true
+		t.ts.Close()
+	}
+}
+
+func (t *Tree) node(n *sitter.Node) *Node {
+	if n == nil {
+		return nil
+	}
+	return &Node{ts: n, tree: t}
+}
+
+func (t *Tree) lines() *lineIndex {
+	t.linesOnce.Do(func() { t.lineIndex = newLineIndex(t.src) })
+	return t.lineIndex
+}
+
+// NodeAt returns the smallest node at a byte offset, preferring the node
+// that starts there when the offset sits between two tokens. It returns nil
+// if the offset is outside the root node.
+func (t *Tree) NodeAt(offset uint32) *Node {
+	return t.RootNode().DescendantForByteRange(offset, offset)
+}
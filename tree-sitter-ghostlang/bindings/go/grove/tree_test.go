@@ -0,0 +1,97 @@
+package grove_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+// TestTreeCopyConcurrent runs read passes over copies of one tree from
+// several goroutines at once; run it with -race to check the contract.
+func TestTreeCopyConcurrent(t *testing.T) {
+	src := largeSource(50)
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	tree, err := p.Parse(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tree.Close()
+
+	foldSource, err := os.ReadFile("../../../queries/folds.scm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	folds, err := grove.NewQuery(ghostlang(t), string(foldSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+	folder := grove.NewFoldProvider(folds)
+	wantSExp := tree.String()
+	wantNodes := countNodes(tree)
+	wantFolds := len(folder.Folds(tree))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		c := tree.Copy()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer c.Close()
+			switch i % 3 {
+			case 0:
+				if c.String() != wantSExp {
+					t.Errorf("copy %d: S-expression differs from the original", i)
+				}
+			case 1:
+				if n := countNodes(c); n != wantNodes {
+					t.Errorf("copy %d: walked %d nodes, want %d", i, n, wantNodes)
+				}
+			case 2:
+				if n := len(folder.Folds(c)); n != wantFolds {
+					t.Errorf("copy %d: %d folds, want %d", i, n, wantFolds)
+				}
+			}
+		}(i)
+	}
+	// The original stays usable on this goroutine meanwhile.
+	if n := countNodes(tree); n != wantNodes {
+		t.Errorf("original: walked %d nodes, want %d", n, wantNodes)
+	}
+	wg.Wait()
+	if tree.String() != wantSExp {
+		t.Errorf("original changed after its copies were closed")
+	}
+}
+
+func TestTreeCopyEditIsIndependent(t *testing.T) {
+	src := "var x = 1;\n"
+	tree := parse(t, src)
+	defer tree.Close()
+	want := tree.String()
+
+	c := tree.Copy()
+	defer c.Close()
+	next, edit := replace(src, 8, 9, "f(1)")
+	c.Edit(edit)
+	p := newParser(t, ghostlang(t))
+	defer p.Close()
+	edited := reparse(t, p, c, next)
+	defer edited.Close()
+
+	if tree.String() != want {
+		t.Errorf("editing a copy changed the original: %s", tree)
+	}
+	if edited.String() == want {
+		t.Errorf("re-parsed copy did not pick up the edit")
+	}
+}
+
+func countNodes(tree *grove.Tree) int {
+	c := tree.Walk()
+	defer c.Close()
+	return countWithCursor(c)
+}
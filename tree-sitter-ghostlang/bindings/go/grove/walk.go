@@ -0,0 +1,36 @@
+package grove
+
+import sitter "github.com/smacker/go-tree-sitter"
+
+// Walk visits root and its descendants in pre-order, which is source order.
+// When fn returns false for a node, the node's children are skipped.
+func Walk(root *Node, fn func(n *Node) bool) {
+	walk(root, false, fn)
+}
+
+// WalkNamed is like Walk but only calls fn for named nodes, skipping
+// anonymous tokens such as punctuation and keywords.
+func WalkNamed(root *Node, fn func(n *Node) bool) {
+	walk(root, true, fn)
+}
+
+func walk(root *Node, namedOnly bool, fn func(n *Node) bool) {
+	c := sitter.NewTreeCursor(root.live())
+	defer c.Close()
+	for {
+		descend := true
+		if n := c.CurrentNode(); !namedOnly || n.IsNamed() {
+			descend = fn(root.tree.node(n))
+		}
+		if descend && c.GoToFirstChild() {
+			continue
+		}
+		// The cursor treats root as the top of the tree, so climbing out
+		// of it ends the walk even when root has siblings.
+		for !c.GoToNextSibling() {
+			if !c.GoToParent() {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package grove_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tree-sitter/tree-sitter-ghostlang/grove"
+)
+
+func TestWalk(t *testing.T) {
+	tree := parse(t, "function f(a) { return a; }\nvar x = f(1);\n")
+
+	var kinds []string
+	var last uint32
+	grove.Walk(tree.RootNode(), func(n *grove.Node) bool {
+		if n.StartByte() < last {
+			t.Errorf("%s at %d visited after a node at %d", n.Kind(), n.StartByte(), last)
+		}
+		last = n.StartByte()
+		kinds = append(kinds, n.Kind())
+		return n.Kind() != "function_declaration" && n.Kind() != "variable_declaration"
+	})
+	want := []string{"source_file", "statement", "function_declaration", "statement", "variable_declaration"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("Walk visited %v, want %v", kinds, want)
+	}
+}
+
+func TestWalkNamed(t *testing.T) {
+	tree := parse(t, "function f(a, b) {}")
+	params := tree.RootNode().NamedChild(0).NamedChild(0).NamedChild(1)
+
+	var kinds []string
+	grove.WalkNamed(params, func(n *grove.Node) bool {
+		kinds = append(kinds, n.Kind())
+		return true
+	})
+	want := []string{"parameter_list", "identifier", "identifier"}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("WalkNamed visited %v, want %v", kinds, want)
+	}
+
+	count := 0
+	grove.Walk(params, func(*grove.Node) bool { count++; return true })
+	if count != 6 {
+		t.Errorf("Walk visited %d nodes of the parameter list, want 6", count)
+	}
+}
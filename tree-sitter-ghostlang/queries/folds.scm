@@ -0,0 +1,10 @@
+; Fold queries for Ghostlang
+; These tell Grove which regions an editor can collapse
+
+(block_statement) @fold
+(object_literal) @fold
+(array_literal) @fold
+(argument_list) @fold
+(parameter_list) @fold
+
+(comment) @fold.comment
@@ -0,0 +1,21 @@
+; Indentation queries for Ghostlang
+; These let Grove compute auto-indent levels
+
+[
+  (block_statement)
+  (object_literal)
+  (array_literal)
+  (argument_list)
+  (parameter_list)
+] @indent.begin
+
+[
+  "}"
+  "]"
+  ")"
+] @indent.end
+
+[
+  (comment)
+  (string_literal)
+] @indent.ignore
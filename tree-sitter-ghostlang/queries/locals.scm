@@ -3,28 +3,32 @@
 
 ; Scopes
 (source_file) @local.scope
-(function_declaration body: (_) @local.scope)
+(function_declaration) @local.scope
 (block_statement) @local.scope
+(for_statement) @local.scope
 
-; Function definitions create their own scope
-(function_declaration
-  name: (identifier) @local.definition.function
-  body: (_) @local.scope) @local.symbol.function
+; Function definitions create their own scope. The function's name belongs
+; to the scope around it, so that callers can see it, which is marked with
+; the definition.<kind>.scope property used by nvim-treesitter.
+((function_declaration
+  name: (identifier) @local.definition.function) @local.symbol.function
+ (#set! definition.function.scope "parent"))
 
 ; Variable definitions
 (variable_declaration
   name: (identifier) @local.definition.variable) @local.symbol.variable
 
+(for_statement
+  variable: (identifier) @local.definition.variable)
+
 ; Parameter definitions
 (parameter_list
   (identifier) @local.definition.parameter)
 
-; Variable references
-(identifier) @local.reference
-
-; Member access doesn't count as variable reference
-(member_expression
-  property: (identifier) @_not_reference)
+; Variable references. Identifiers elsewhere, such as member properties and
+; object keys, are names rather than references.
+(primary_expression
+  (identifier) @local.reference)
 
 ; Function calls - function name is a reference
 (call_expression
@@ -36,4 +40,4 @@
 (assignment_expression
   left: (postfix_expression
     (primary_expression
-      (identifier) @local.reference)))
\ No newline at end of file
+      (identifier) @local.reference)))